@@ -37,7 +37,7 @@ func main() {
 	fmt.Printf("ID: %d - Name: %s - Public: %v\n", node.ID, node.Name, node.Public)
 
 	data := node.UpdateDescriptor()
-	data.Public = false
+	data.Public = gator.Ptr(false)
 	node, err = app.UpdateNode(node.ID, *data)
 	if err != nil {
 		fmt.Printf("%#v", err)
@@ -60,6 +60,21 @@ func main() {
 		fmt.Printf("%d: %s\n", n.ID, n.Name)
 	}
 
+	// Nodes in location 1 with room for a 1 GB server
+	deployable, err := app.ListDeployableNodes(gator.DeployableNodesDescriptor{
+		Memory:       1024,
+		Disk:         1024,
+		LocationsIDs: []int{1},
+	})
+	if err != nil {
+		fmt.Printf("%#v", err)
+		return
+	}
+
+	for _, n := range deployable {
+		fmt.Printf("deployable %d: %s\n", n.ID, n.Name)
+	}
+
 	if err = app.DeleteNode(node.ID); err != nil {
 		handleError(err)
 	}
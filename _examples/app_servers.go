@@ -34,7 +34,7 @@ func main() {
 	fmt.Printf("ID: %d - Name: %s - ExternalID: %s\n", server.ID, server.Name, server.ExternalID)
 
 	data := server.DetailsDescriptor()
-	data.ExternalID = "gator"
+	data.ExternalID = gator.Ptr("gator")
 	server, err = app.UpdateServerDetails(server.ID, *data)
 	if err != nil {
 		fmt.Printf("%#v", err)
@@ -25,7 +25,7 @@ func main() {
 	fmt.Printf("ID: %d - Name: %s - RootAdmin: %v\n", user.ID, user.Username, user.RootAdmin)
 
 	data := user.UpdateDescriptor()
-	data.RootAdmin = true
+	data.RootAdmin = gator.Ptr(true)
 	user, err = app.UpdateUser(user.ID, *data)
 	if err != nil {
 		fmt.Printf("%#v", err)
@@ -1,48 +1,137 @@
 package alligator
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const Version = "1.1.0"
 
 type Application struct {
-	PanelURL string
-	ApiKey   string
-	Http     *http.Client
+	PanelURL   string
+	ApiKey     string
+	Http       *http.Client
+	Retry      *RetryPolicy
+	Limiter    *RateLimiter
+	Logger     *slog.Logger
+	UserAgent  string
+	Headers    http.Header // Extra headers sent with every request
+	Middleware []Middleware
 }
 
 type Client struct {
-	PanelURL string
-	ApiKey   string
-	Http     *http.Client
+	PanelURL   string
+	ApiKey     string
+	Http       *http.Client
+	Retry      *RetryPolicy
+	Limiter    *RateLimiter
+	Logger     *slog.Logger
+	UserAgent  string
+	Headers    http.Header // Extra headers sent with every request
+	Middleware []Middleware
 }
 
-func NewApp(url, key string) (*Application, error) {
-	if url == "" {
+// sender holds the transport settings shared by Application and Client.
+type sender struct {
+	http    *http.Client
+	retry   *RetryPolicy
+	limiter *RateLimiter
+	logger  *slog.Logger
+	mws     []Middleware
+}
+
+func (s *sender) do(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if s.retry.allows(req) {
+		attempts = s.retry.MaxAttempts
+	}
+
+	send := chain(s.http.Do, s.mws)
+	for attempt := 1; ; attempt++ {
+		if s.limiter != nil {
+			if err := s.limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		if s.logger != nil {
+			s.logRequest(req)
+		}
+
+		start := time.Now()
+		res, err := send(req)
+		if s.logger != nil {
+			s.logResponse(req, res, err, time.Since(start), attempt)
+		}
+		if err == nil && s.limiter != nil {
+			s.limiter.Update(res)
+		}
+		if attempt >= attempts || !s.retry.retryable(req, res, err) {
+			return res, err
+		}
+
+		delay := s.retry.delay(attempt, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if err = sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func NewApp(panelURL, key string, opts ...Option) (*Application, error) {
+	if panelURL == "" {
 		return nil, errors.New("a valid panel url is required")
 	}
 	if key == "" {
 		return nil, errors.New("a valid application api key is required")
 	}
+	if strings.HasPrefix(key, clientKeyPrefix) {
+		return nil, errors.New("a client api key cannot be used with the application api")
+	}
+
+	base, err := normalizeURL(panelURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSettings(key, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	app := &Application{
-		PanelURL: url,
-		ApiKey:   key,
-		Http:     &http.Client{},
+		PanelURL:   base,
+		ApiKey:     key,
+		Http:       s.http,
+		Retry:      s.retry,
+		Limiter:    s.limiter,
+		Logger:     s.logger,
+		UserAgent:  s.userAgent,
+		Headers:    s.headers,
+		Middleware: s.middleware,
 	}
 
 	return app, nil
 }
 
-func (a *Application) newRequest(method, path string, body io.Reader) *http.Request {
-	req, _ := http.NewRequest(method, fmt.Sprintf("%s/api/application%s", a.PanelURL, path), body)
+func (a *Application) newRequest(ctx context.Context, method, path string, body io.Reader) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/api/application%s", a.PanelURL, path), body)
 
-	req.Header.Set("User-Agent", "Alligator v"+Version)
+	setBaseHeaders(req, a.Headers, a.UserAgent)
 	req.Header.Set("Authorization", "Bearer "+a.ApiKey)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -50,27 +139,51 @@ func (a *Application) newRequest(method, path string, body io.Reader) *http.Requ
 	return req
 }
 
-func NewClient(url, key string) (*Client, error) {
-	if url == "" {
+func (a *Application) do(req *http.Request) (*http.Response, error) {
+	s := &sender{http: a.Http, retry: a.Retry, limiter: a.Limiter, logger: a.Logger, mws: a.Middleware}
+	return s.do(req)
+}
+
+func NewClient(panelURL, key string, opts ...Option) (*Client, error) {
+	if panelURL == "" {
 		return nil, errors.New("a valid panel url is required")
 	}
 	if key == "" {
 		return nil, errors.New("a valid client api key is required")
 	}
+	if strings.HasPrefix(key, applicationKeyPrefix) {
+		return nil, errors.New("an application api key cannot be used with the client api")
+	}
+
+	base, err := normalizeURL(panelURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSettings(key, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	client := &Client{
-		PanelURL: url,
-		ApiKey:   key,
-		Http:     &http.Client{},
+		PanelURL:   base,
+		ApiKey:     key,
+		Http:       s.http,
+		Retry:      s.retry,
+		Limiter:    s.limiter,
+		Logger:     s.logger,
+		UserAgent:  s.userAgent,
+		Headers:    s.headers,
+		Middleware: s.middleware,
 	}
 
 	return client, nil
 }
 
-func (c *Client) newRequest(method, path string, body io.Reader) *http.Request {
-	req, _ := http.NewRequest(method, fmt.Sprintf("%s/api/client%s", c.PanelURL, path), body)
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/api/client%s", c.PanelURL, path), body)
 
-	req.Header.Set("User-Agent", "Alligator v"+Version)
+	setBaseHeaders(req, c.Headers, c.UserAgent)
 	req.Header.Set("Authorization", "Bearer "+c.ApiKey)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -78,7 +191,22 @@ func (c *Client) newRequest(method, path string, body io.Reader) *http.Request {
 	return req
 }
 
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	s := &sender{http: c.Http, retry: c.Retry, limiter: c.Limiter, logger: c.Logger, mws: c.Middleware}
+	return s.do(req)
+}
+
+// Ptr returns a pointer to v, for the optional fields of update descriptors where nil
+// leaves the value unchanged and a pointer to the zero value clears it.
+//
+//	app.UpdateUser(id, gator.UpdateUserDescriptor{RootAdmin: gator.Ptr(false)})
+func Ptr[T any](v T) *T {
+	return &v
+}
+
 func validate(res *http.Response) ([]byte, error) {
+	defer res.Body.Close()
+
 	switch res.StatusCode {
 	case http.StatusOK:
 		fallthrough
@@ -87,7 +215,6 @@ func validate(res *http.Response) ([]byte, error) {
 		fallthrough
 
 	case http.StatusAccepted:
-		defer res.Body.Close()
 		buf, _ := io.ReadAll(res.Body)
 		return buf, nil
 
@@ -95,14 +222,7 @@ func validate(res *http.Response) ([]byte, error) {
 		return nil, nil
 
 	default:
-		defer res.Body.Close()
 		buf, _ := io.ReadAll(res.Body)
-
-		var errs *ApiError
-		if err := json.Unmarshal(buf, &errs); err != nil {
-			return nil, err
-		}
-
-		return nil, errs
+		return nil, newApiError(res, buf)
 	}
 }
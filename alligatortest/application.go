@@ -0,0 +1,1489 @@
+package alligatortest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/m41denx/alligator"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (p *Panel) applicationRoutes(mux *http.ServeMux) {
+	routes := map[string]http.HandlerFunc{
+		"GET /users":                                       p.listUsers,
+		"POST /users":                                      p.createUser,
+		"GET /users/{id}":                                  p.getUser,
+		"GET /users/external/{id}":                         p.getUserExternal,
+		"PATCH /users/{id}":                                p.updateUser,
+		"DELETE /users/{id}":                               p.deleteUser,
+		"GET /locations":                                   p.listLocations,
+		"POST /locations":                                  p.createLocation,
+		"GET /locations/{id}":                              p.getLocation,
+		"PATCH /locations/{id}":                            p.updateLocation,
+		"DELETE /locations/{id}":                           p.deleteLocation,
+		"GET /nodes":                                       p.listNodes,
+		"POST /nodes":                                      p.createNode,
+		"GET /nodes/deployable":                            p.listDeployableNodes,
+		"GET /nodes/{id}":                                  p.getNode,
+		"PATCH /nodes/{id}":                                p.updateNode,
+		"DELETE /nodes/{id}":                               p.deleteNode,
+		"GET /nodes/{id}/configuration":                    p.getNodeConfiguration,
+		"GET /nodes/{id}/allocations":                      p.listAllocations,
+		"POST /nodes/{id}/allocations":                     p.createAllocations,
+		"DELETE /nodes/{id}/allocations/{alloc}":           p.deleteAllocation,
+		"GET /nests":                                       p.listNests,
+		"GET /nests/{id}":                                  p.getNest,
+		"GET /nests/{id}/eggs":                             p.listEggs,
+		"GET /nests/{id}/eggs/{egg}":                       p.getEgg,
+		"GET /servers":                                     p.listServers,
+		"POST /servers":                                    p.createServer,
+		"GET /servers/{id}":                                p.getServer,
+		"PATCH /servers/{id}/details":                      p.updateServerDetails,
+		"PATCH /servers/{id}/build":                        p.updateServerBuild,
+		"PATCH /servers/{id}/startup":                      p.updateServerStartup,
+		"POST /servers/{id}/suspend":                       p.suspendServer,
+		"POST /servers/{id}/unsuspend":                     p.unsuspendServer,
+		"POST /servers/{id}/reinstall":                     p.reinstallServer,
+		"DELETE /servers/{id}":                             p.deleteServer,
+		"DELETE /servers/{id}/force":                       p.deleteServer,
+		"GET /servers/{id}/{sub}":                          p.serverSubresource,
+		"POST /servers/{id}/databases":                     p.createServerDatabase,
+		"GET /servers/{id}/databases/{db}":                 p.getServerDatabase,
+		"POST /servers/{id}/databases/{db}/reset-password": p.resetServerDatabasePassword,
+		"DELETE /servers/{id}/databases/{db}":              p.deleteServerDatabase,
+		"GET /mounts":                                      p.listMounts,
+		"POST /mounts":                                     p.createMount,
+		"GET /mounts/{id}":                                 p.getMount,
+		"PATCH /mounts/{id}":                               p.updateMount,
+		"DELETE /mounts/{id}":                              p.deleteMount,
+		"POST /mounts/{id}/eggs":                           p.attachMountEggs,
+		"DELETE /mounts/{id}/eggs/{egg}":                   p.detachMountEgg,
+		"POST /mounts/{id}/nodes":                          p.attachMountNodes,
+		"DELETE /mounts/{id}/nodes/{node}":                 p.detachMountNode,
+	}
+
+	for route, h := range routes {
+		method, path, _ := strings.Cut(route, " ")
+		mux.HandleFunc(method+" /api/application"+path, p.application(h))
+	}
+}
+
+func (p *Panel) application(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if _, ok := p.clientKeys[key]; ok {
+			writeError(w, http.StatusForbidden, "This action is unauthorized.")
+			return
+		}
+		if key != p.AppKey {
+			writeError(w, http.StatusUnauthorized, "Unauthenticated.")
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		h(w, r)
+	}
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "The requested resource could not be found on the server.")
+}
+
+// Documents
+
+func (p *Panel) userDoc(u *alligator.User, inc map[string]bool) document {
+	if u == nil {
+		return item("null_resource", nil)
+	}
+
+	attrs := attributes(u)
+	rel := document{}
+	if inc["servers"] {
+		rel["servers"] = list(p.serverDocs(func(s *server) bool { return s.UserID == u.ID }))
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("user", attrs)
+}
+
+func (p *Panel) locationDoc(l *alligator.Location, inc map[string]bool) document {
+	if l == nil {
+		return item("null_resource", nil)
+	}
+
+	attrs := attributes(l)
+	rel := document{}
+	if inc["nodes"] {
+		rel["nodes"] = list(p.nodeDocs(func(n *alligator.Node) bool { return n.LocationID == l.ID }))
+	}
+	if inc["servers"] {
+		rel["servers"] = list(p.serverDocs(func(s *server) bool {
+			n := p.nodes[s.NodeID]
+			return n != nil && n.LocationID == l.ID
+		}))
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("location", attrs)
+}
+
+func (p *Panel) nodeDoc(n *alligator.Node, inc map[string]bool) document {
+	if n == nil {
+		return item("null_resource", nil)
+	}
+
+	attrs := attributes(n)
+	rel := document{}
+	if inc["allocations"] {
+		rel["allocations"] = list(p.allocationDocs(func(a *allocation) bool { return a.node == n.ID }))
+	}
+	if inc["location"] {
+		rel["location"] = p.locationDoc(p.locations[n.LocationID], nil)
+	}
+	if inc["servers"] {
+		rel["servers"] = list(p.serverDocs(func(s *server) bool { return s.NodeID == n.ID }))
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("node", attrs)
+}
+
+func (p *Panel) allocationDoc(a *allocation, inc map[string]bool) document {
+	attrs := attributes(a.Allocation)
+	rel := document{}
+	if inc["node"] {
+		rel["node"] = p.nodeDoc(p.nodes[a.node], nil)
+	}
+	if inc["server"] {
+		if s, ok := p.servers[a.server]; ok {
+			rel["server"] = item("server", attributes(s.AppServer))
+		} else {
+			rel["server"] = item("null_resource", nil)
+		}
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("allocation", attrs)
+}
+
+func (p *Panel) nestDoc(n *alligator.Nest, inc map[string]bool) document {
+	if n == nil {
+		return item("null_resource", nil)
+	}
+
+	attrs := attributes(n)
+	rel := document{}
+	if inc["eggs"] {
+		var eggs []document
+		for _, id := range sortedKeys(p.eggs) {
+			if e := p.eggs[id]; e.NestID == n.ID {
+				eggs = append(eggs, p.eggDoc(e, nil))
+			}
+		}
+		rel["eggs"] = list(eggs)
+	}
+	if inc["servers"] {
+		rel["servers"] = list(p.serverDocs(func(s *server) bool { return s.NestID == n.ID }))
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("nest", attrs)
+}
+
+func (p *Panel) eggDoc(e *alligator.Egg, inc map[string]bool) document {
+	if e == nil {
+		return item("null_resource", nil)
+	}
+
+	attrs := attributes(e)
+	rel := document{}
+	if inc["nest"] {
+		rel["nest"] = p.nestDoc(p.nests[e.NestID], nil)
+	}
+	if inc["servers"] {
+		rel["servers"] = list(p.serverDocs(func(s *server) bool { return s.EggID == e.ID }))
+	}
+	if inc["variables"] {
+		vars := make([]document, 0, len(e.Variables))
+		for _, v := range e.Variables {
+			vars = append(vars, item("egg_variable", attributes(v)))
+		}
+		rel["variables"] = list(vars)
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("egg", attrs)
+}
+
+func (p *Panel) serverDoc(s *server, inc map[string]bool) document {
+	attrs := attributes(s.AppServer)
+	rel := document{}
+	if inc["allocations"] {
+		rel["allocations"] = list(p.allocationDocs(func(a *allocation) bool { return a.server == s.ID }))
+	}
+	if inc["user"] {
+		rel["user"] = p.userDoc(p.users[s.UserID], nil)
+	}
+	if inc["subusers"] {
+		rel["subusers"] = list(nil)
+	}
+	if inc["location"] {
+		var loc *alligator.Location
+		if n, ok := p.nodes[s.NodeID]; ok {
+			loc = p.locations[n.LocationID]
+		}
+		rel["location"] = p.locationDoc(loc, nil)
+	}
+	if inc["node"] {
+		rel["node"] = p.nodeDoc(p.nodes[s.NodeID], nil)
+	}
+	if inc["nest"] {
+		rel["nest"] = p.nestDoc(p.nests[s.NestID], nil)
+	}
+	if inc["egg"] {
+		rel["egg"] = p.eggDoc(p.eggs[s.EggID], nil)
+	}
+	if inc["variables"] {
+		var vars []document
+		if egg, ok := p.eggs[s.EggID]; ok {
+			for _, v := range egg.Variables {
+				attrs := attributes(v)
+				attrs["server_value"] = s.Container.Environment[v.EnvVariable]
+				vars = append(vars, item("server_variable", attrs))
+			}
+		}
+		rel["variables"] = list(vars)
+	}
+	if inc["databases"] {
+		dbs := make([]document, 0, len(s.databases))
+		for _, db := range s.databases {
+			dbs = append(dbs, p.databaseDoc(db, nil))
+		}
+		rel["databases"] = list(dbs)
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("server", attrs)
+}
+
+func (p *Panel) serverDocs(match func(*server) bool) []document {
+	var docs []document
+	for _, id := range sortedKeys(p.servers) {
+		if s := p.servers[id]; match(s) {
+			docs = append(docs, item("server", attributes(s.AppServer)))
+		}
+	}
+	return docs
+}
+
+func (p *Panel) nodeDocs(match func(*alligator.Node) bool) []document {
+	var docs []document
+	for _, id := range sortedKeys(p.nodes) {
+		if n := p.nodes[id]; match(n) {
+			docs = append(docs, item("node", attributes(n)))
+		}
+	}
+	return docs
+}
+
+func (p *Panel) allocationDocs(match func(*allocation) bool) []document {
+	var docs []document
+	for _, id := range sortedKeys(p.allocations) {
+		if a := p.allocations[id]; match(a) {
+			docs = append(docs, item("allocation", attributes(a.Allocation)))
+		}
+	}
+	return docs
+}
+
+// Users
+
+func (p *Panel) listUsers(w http.ResponseWriter, r *http.Request) {
+	inc := includes(r)
+	var docs []document
+	for _, id := range sortedKeys(p.users) {
+		docs = append(docs, p.userDoc(p.users[id], inc))
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) getUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	u, ok := p.users[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.userDoc(u, includes(r)))
+}
+
+func (p *Panel) getUserExternal(w http.ResponseWriter, r *http.Request) {
+	for _, u := range p.users {
+		if u.ExternalID != "" && u.ExternalID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, p.userDoc(u, includes(r)))
+			return
+		}
+	}
+	notFound(w)
+}
+
+func (p *Panel) validateUser(u *alligator.User) []fieldError {
+	var errs []fieldError
+	errs = required(errs, "email", u.Email != "")
+	errs = required(errs, "username", u.Username != "")
+	errs = required(errs, "first_name", u.FirstName != "")
+	errs = required(errs, "last_name", u.LastName != "")
+
+	for _, other := range p.users {
+		if other.ID == u.ID {
+			continue
+		}
+		if u.Email != "" && strings.EqualFold(other.Email, u.Email) {
+			errs = append(errs, fieldError{"email", "unique", "The email has already been taken."})
+		}
+		if u.Username != "" && strings.EqualFold(other.Username, u.Username) {
+			errs = append(errs, fieldError{"username", "unique", "The username has already been taken."})
+		}
+	}
+
+	return errs
+}
+
+func (p *Panel) createUser(w http.ResponseWriter, r *http.Request) {
+	var u alligator.User
+	if err := decode(r, &u); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	u.ID = 0
+
+	if errs := p.validateUser(&u); len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	u.ID = p.next("user")
+	u.UUID = newUUID()
+	if u.Language == "" {
+		u.Language = "en"
+	}
+	u.CreatedAt = now()
+	u.UpdatedAt = u.CreatedAt
+	p.users[u.ID] = &u
+
+	writeJSON(w, http.StatusCreated, p.userDoc(&u, nil))
+}
+
+func (p *Panel) updateUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	u, ok := p.users[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	updated := *u
+	if err := decode(r, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated.ID, updated.UUID, updated.CreatedAt = u.ID, u.UUID, u.CreatedAt
+
+	if errs := p.validateUser(&updated); len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	updated.UpdatedAt = now()
+	p.users[id] = &updated
+
+	writeJSON(w, http.StatusOK, p.userDoc(&updated, nil))
+}
+
+func (p *Panel) deleteUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	if _, ok := p.users[id]; !ok {
+		notFound(w)
+		return
+	}
+	for _, s := range p.servers {
+		if s.UserID == id {
+			writeError(w, http.StatusBadRequest, "Cannot delete a user with active servers attached to their account.")
+			return
+		}
+	}
+
+	delete(p.users, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Locations
+
+func (p *Panel) listLocations(w http.ResponseWriter, r *http.Request) {
+	inc := includes(r)
+	var docs []document
+	for _, id := range sortedKeys(p.locations) {
+		docs = append(docs, p.locationDoc(p.locations[id], inc))
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) getLocation(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	l, ok := p.locations[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.locationDoc(l, includes(r)))
+}
+
+func (p *Panel) createLocation(w http.ResponseWriter, r *http.Request) {
+	var l alligator.Location
+	if err := decode(r, &l); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var errs []fieldError
+	errs = required(errs, "short", l.Short != "")
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	l.ID = p.next("location")
+	l.CreatedAt = now()
+	l.UpdatedAt = l.CreatedAt
+	p.locations[l.ID] = &l
+
+	writeJSON(w, http.StatusCreated, p.locationDoc(&l, nil))
+}
+
+func (p *Panel) updateLocation(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	l, ok := p.locations[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	updated := *l
+	if err := decode(r, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated.ID, updated.CreatedAt, updated.UpdatedAt = l.ID, l.CreatedAt, now()
+	p.locations[id] = &updated
+
+	writeJSON(w, http.StatusOK, p.locationDoc(&updated, nil))
+}
+
+func (p *Panel) deleteLocation(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	if _, ok := p.locations[id]; !ok {
+		notFound(w)
+		return
+	}
+	for _, n := range p.nodes {
+		if n.LocationID == id {
+			writeError(w, http.StatusBadRequest, "Cannot delete a location that has active nodes attached to it.")
+			return
+		}
+	}
+
+	delete(p.locations, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Nodes
+
+func (p *Panel) listNodes(w http.ResponseWriter, r *http.Request) {
+	inc := includes(r)
+	var docs []document
+	for _, id := range sortedKeys(p.nodes) {
+		docs = append(docs, p.nodeDoc(p.nodes[id], inc))
+	}
+	writeList(w, r, docs)
+}
+
+// listDeployableNodes mirrors the panel's viable node lookup: public nodes in the requested
+// locations whose memory and disk, overallocation included, fit another server.
+func (p *Panel) listDeployableNodes(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	memory, errMemory := strconv.ParseInt(q.Get("memory"), 10, 64)
+	disk, errDisk := strconv.ParseInt(q.Get("disk"), 10, 64)
+
+	var errs []fieldError
+	errs = required(errs, "memory", errMemory == nil)
+	errs = required(errs, "disk", errDisk == nil)
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	locations := make(map[int]bool)
+	for _, v := range q["location_ids[]"] {
+		id, _ := strconv.Atoi(v)
+		locations[id] = true
+	}
+
+	fits := func(used, requested, total, over int64) bool {
+		return over < 0 || used+requested <= total*(100+over)/100
+	}
+
+	var docs []document
+	for _, id := range sortedKeys(p.nodes) {
+		n := p.nodes[id]
+		if !n.Public || (len(locations) > 0 && !locations[n.LocationID]) {
+			continue
+		}
+
+		var usedMemory, usedDisk int64
+		for _, s := range p.servers {
+			if s.NodeID == n.ID {
+				usedMemory += s.Limits.Memory
+				usedDisk += s.Limits.Disk
+			}
+		}
+		if fits(usedMemory, memory, n.Memory, n.MemoryOverallocate) && fits(usedDisk, disk, n.Disk, n.DiskOverallocate) {
+			docs = append(docs, p.nodeDoc(n, nil))
+		}
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) getNode(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	n, ok := p.nodes[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.nodeDoc(n, includes(r)))
+}
+
+func (p *Panel) validateNode(n *alligator.Node) []fieldError {
+	var errs []fieldError
+	errs = required(errs, "name", n.Name != "")
+	errs = required(errs, "fqdn", n.FQDN != "")
+	if _, ok := p.locations[n.LocationID]; !ok {
+		errs = append(errs, fieldError{"location_id", "exists", "The selected location id is invalid."})
+	}
+	return errs
+}
+
+func (p *Panel) createNode(w http.ResponseWriter, r *http.Request) {
+	var n alligator.Node
+	if err := decode(r, &n); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errs := p.validateNode(&n); len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	n.ID = p.next("node")
+	n.CreatedAt = now()
+	n.UpdatedAt = n.CreatedAt
+	p.nodes[n.ID] = &n
+
+	writeJSON(w, http.StatusCreated, p.nodeDoc(&n, nil))
+}
+
+func (p *Panel) updateNode(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	n, ok := p.nodes[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	updated := *n
+	if err := decode(r, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated.ID, updated.CreatedAt = n.ID, n.CreatedAt
+
+	if errs := p.validateNode(&updated); len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	updated.UpdatedAt = now()
+	p.nodes[id] = &updated
+
+	writeJSON(w, http.StatusOK, p.nodeDoc(&updated, nil))
+}
+
+func (p *Panel) deleteNode(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	if _, ok := p.nodes[id]; !ok {
+		notFound(w)
+		return
+	}
+	for _, s := range p.servers {
+		if s.NodeID == id {
+			writeError(w, http.StatusBadRequest, "Cannot delete a node with active servers attached to it.")
+			return
+		}
+	}
+
+	for aid, a := range p.allocations {
+		if a.node == id {
+			delete(p.allocations, aid)
+		}
+	}
+	delete(p.nodes, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) getNodeConfiguration(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	n, ok := p.nodes[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	cfg := alligator.NodeConfiguration{
+		UUID:    newUUID(),
+		TokenID: randomString(16),
+		Token:   randomString(64),
+		Remote:  p.URL,
+	}
+	cfg.API.Host = "0.0.0.0"
+	cfg.API.Port = n.DaemonListen
+	cfg.API.SSL.Enabled = n.Scheme == "https"
+	cfg.API.UploadLimit = n.UploadSize
+	cfg.System.Data = n.DaemonBase
+	cfg.System.SFTP.BindPort = n.DaemonSftp
+	cfg.AllowedMounts = []string{}
+
+	writeJSON(w, http.StatusOK, cfg)
+}
+
+// Allocations
+
+func (p *Panel) listAllocations(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	if _, ok := p.nodes[id]; !ok {
+		notFound(w)
+		return
+	}
+
+	// ip_alias and server_id are columns the transformer doesn't expose
+	q := r.URL.Query()
+	alias, filterAlias := q["filter[ip_alias]"]
+	server, filterServer := q["filter[server_id]"]
+
+	inc := includes(r)
+	var docs []document
+	for _, aid := range sortedKeys(p.allocations) {
+		a := p.allocations[aid]
+		if a.node != id {
+			continue
+		}
+		if filterAlias && !strings.Contains(strings.ToLower(a.Alias), strings.ToLower(alias[0])) {
+			continue
+		}
+		if filterServer && strconv.Itoa(a.server) != server[0] {
+			continue
+		}
+		docs = append(docs, p.allocationDoc(a, inc))
+	}
+	writeList(w, r, docs, "ip_alias", "server_id")
+}
+
+func (p *Panel) createAllocations(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	if _, ok := p.nodes[id]; !ok {
+		notFound(w)
+		return
+	}
+
+	var body struct {
+		IP    string   `json:"ip"`
+		Alias string   `json:"alias"`
+		Ports []string `json:"ports"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var errs []fieldError
+	errs = required(errs, "ip", body.IP != "")
+	errs = required(errs, "ports", len(body.Ports) > 0)
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	var ports []int32
+	for _, spec := range body.Ports {
+		from, to, isRange := strings.Cut(spec, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		end := start
+		if err == nil && isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(to))
+		}
+		if err != nil || start < 1024 || end > 65535 || end < start {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("The mapping provided for %s was invalid.", spec))
+			return
+		}
+		for port := start; port <= end; port++ {
+			ports = append(ports, int32(port))
+		}
+	}
+
+	for _, port := range ports {
+		exists := false
+		for _, a := range p.allocations {
+			if a.node == id && a.IP == body.IP && a.Port == port {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+
+		alloc := &alligator.Allocation{ID: p.next("allocation"), IP: body.IP, Alias: body.Alias, Port: port}
+		p.allocations[alloc.ID] = &allocation{Allocation: alloc, node: id}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) deleteAllocation(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	aid, _ := pathID(r, "alloc")
+	a, ok := p.allocations[aid]
+	if !ok || a.node != id {
+		notFound(w)
+		return
+	}
+	if a.server != 0 {
+		writeError(w, http.StatusBadRequest, "Cannot delete an allocation that is currently assigned to a server.")
+		return
+	}
+
+	delete(p.allocations, aid)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Nests & eggs
+
+func (p *Panel) listNests(w http.ResponseWriter, r *http.Request) {
+	inc := includes(r)
+	var docs []document
+	for _, id := range sortedKeys(p.nests) {
+		docs = append(docs, p.nestDoc(p.nests[id], inc))
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) getNest(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	n, ok := p.nests[id]
+	if !ok {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.nestDoc(n, includes(r)))
+}
+
+func (p *Panel) listEggs(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	if _, ok := p.nests[id]; !ok {
+		notFound(w)
+		return
+	}
+
+	inc := includes(r)
+	var docs []document
+	for _, eid := range sortedKeys(p.eggs) {
+		if e := p.eggs[eid]; e.NestID == id {
+			docs = append(docs, p.eggDoc(e, inc))
+		}
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) getEgg(w http.ResponseWriter, r *http.Request) {
+	id, _ := pathID(r, "id")
+	eid, _ := pathID(r, "egg")
+	e, ok := p.eggs[eid]
+	if !ok || e.NestID != id {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.eggDoc(e, includes(r)))
+}
+
+// Servers
+
+func (p *Panel) listServers(w http.ResponseWriter, r *http.Request) {
+	// The panel filters on the uuidShort and image columns, which are exposed as identifier and container.image
+	q := r.URL.Query()
+	short, filterShort := q["filter[uuidShort]"]
+	image, filterImage := q["filter[image]"]
+
+	inc := includes(r)
+	var docs []document
+	for _, id := range sortedKeys(p.servers) {
+		s := p.servers[id]
+		if filterShort && !strings.Contains(strings.ToLower(s.Identifier), strings.ToLower(short[0])) {
+			continue
+		}
+		if filterImage && !strings.Contains(strings.ToLower(s.Container.Image), strings.ToLower(image[0])) {
+			continue
+		}
+		docs = append(docs, p.serverDoc(s, inc))
+	}
+	writeList(w, r, docs, "uuidShort", "image")
+}
+
+func (p *Panel) server(w http.ResponseWriter, r *http.Request) (*server, bool) {
+	id, _ := pathID(r, "id")
+	s, ok := p.servers[id]
+	if !ok {
+		notFound(w)
+	}
+	return s, ok
+}
+
+func (p *Panel) getServer(w http.ResponseWriter, r *http.Request) {
+	if s, ok := p.server(w, r); ok {
+		writeJSON(w, http.StatusOK, p.serverDoc(s, includes(r)))
+	}
+}
+
+// serverSubresource routes GET /servers/external/{id} and /servers/{id}/databases, which
+// ServeMux can't tell apart.
+func (p *Panel) serverSubresource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("id") == "external":
+		p.getServerExternal(w, r)
+	case r.PathValue("sub") == "databases":
+		p.listServerDatabases(w, r)
+	default:
+		notFound(w)
+	}
+}
+
+func (p *Panel) getServerExternal(w http.ResponseWriter, r *http.Request) {
+	for _, s := range p.servers {
+		if s.ExternalID != "" && s.ExternalID == r.PathValue("sub") {
+			writeJSON(w, http.StatusOK, p.serverDoc(s, includes(r)))
+			return
+		}
+	}
+	notFound(w)
+}
+
+func (p *Panel) createServer(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ExternalID    string                  `json:"external_id"`
+		Name          string                  `json:"name"`
+		Description   string                  `json:"description"`
+		User          int                     `json:"user"`
+		Egg           int                     `json:"egg"`
+		DockerImage   string                  `json:"docker_image"`
+		Startup       string                  `json:"startup"`
+		Environment   map[string]interface{}  `json:"environment"`
+		OOMDisabled   bool                    `json:"oom_disabled"`
+		Limits        alligator.Limits        `json:"limits"`
+		FeatureLimits alligator.FeatureLimits `json:"feature_limits"`
+		Allocation    *struct {
+			Default    int   `json:"default"`
+			Additional []int `json:"additional"`
+		} `json:"allocation"`
+		Deploy *struct {
+			Locations []int `json:"locations"`
+		} `json:"deploy"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var errs []fieldError
+	errs = required(errs, "name", body.Name != "")
+	if _, ok := p.users[body.User]; !ok {
+		errs = append(errs, fieldError{"user", "exists", "The selected user is invalid."})
+	}
+	egg, ok := p.eggs[body.Egg]
+	if !ok {
+		errs = append(errs, fieldError{"egg", "exists", "The selected egg is invalid."})
+	} else {
+		errs = environmentErrors(errs, egg, body.Environment)
+	}
+
+	var alloc *allocation
+	switch {
+	case body.Allocation != nil:
+		alloc = p.allocations[body.Allocation.Default]
+		if alloc == nil || alloc.server != 0 {
+			errs = append(errs, fieldError{"allocation.default", "exists", "The selected allocation.default is invalid."})
+		}
+	case body.Deploy != nil:
+		alloc = p.freeAllocation(body.Deploy.Locations)
+		if alloc == nil {
+			writeError(w, http.StatusBadRequest, "No viable allocation could be found for this server.")
+			return
+		}
+	default:
+		errs = required(errs, "allocation.default", false)
+	}
+
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	env := make(map[string]interface{})
+	for _, v := range egg.Variables {
+		env[v.EnvVariable] = v.DefaultValue
+	}
+	for k, v := range body.Environment {
+		env[k] = v
+	}
+
+	srv := &alligator.AppServer{
+		ID:            p.next("server"),
+		ExternalID:    body.ExternalID,
+		UUID:          newUUID(),
+		Name:          body.Name,
+		Description:   body.Description,
+		Limits:        body.Limits,
+		FeatureLimits: body.FeatureLimits,
+		UserID:        body.User,
+		NodeID:        alloc.node,
+		Allocation:    alloc.ID,
+		NestID:        egg.NestID,
+		EggID:         egg.ID,
+		CreatedAt:     now(),
+	}
+	srv.Identifier = srv.UUID[:8]
+	srv.UpdatedAt = srv.CreatedAt
+	srv.Limits.OOMDisabled = body.OOMDisabled
+	srv.Container.StartupCommand = body.Startup
+	if srv.Container.StartupCommand == "" {
+		srv.Container.StartupCommand = egg.Startup
+	}
+	srv.Container.Image = body.DockerImage
+	if srv.Container.Image == "" {
+		srv.Container.Image = egg.DockerImage
+	}
+	srv.Container.Environment = env
+	srv.Container.Installed = 1
+
+	alloc.server, alloc.Assigned = srv.ID, true
+	if body.Allocation != nil {
+		for _, aid := range body.Allocation.Additional {
+			if a, ok := p.allocations[aid]; ok && a.server == 0 && a.node == alloc.node {
+				a.server, a.Assigned = srv.ID, true
+			}
+		}
+	}
+
+	p.servers[srv.ID] = &server{
+		AppServer: srv,
+		state:     "offline",
+		files:     map[string]*file{"/": {dir: true, mode: 0o755}},
+	}
+
+	writeJSON(w, http.StatusCreated, item("server", attributes(srv)))
+}
+
+func (p *Panel) freeAllocation(locations []int) *allocation {
+	for _, id := range sortedKeys(p.allocations) {
+		a := p.allocations[id]
+		n, ok := p.nodes[a.node]
+		if !ok || a.server != 0 || n.MaintenanceMode {
+			continue
+		}
+		for _, l := range locations {
+			if n.LocationID == l {
+				return a
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Panel) updateServerDetails(w http.ResponseWriter, r *http.Request) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return
+	}
+
+	updated := *s.AppServer
+	if err := decode(r, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := p.users[updated.UserID]; !ok {
+		writeValidation(w, []fieldError{{"user", "exists", "The selected user is invalid."}})
+		return
+	}
+
+	s.ExternalID, s.Name, s.UserID, s.Description = updated.ExternalID, updated.Name, updated.UserID, updated.Description
+	s.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, item("server", attributes(s.AppServer)))
+}
+
+func (p *Panel) updateServerBuild(w http.ResponseWriter, r *http.Request) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return
+	}
+
+	var body map[string]json.RawMessage
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Only the keys that were sent are changed
+	limits, features := s.Limits, s.FeatureLimits
+	var add, remove []int
+	allocID := s.Allocation
+	for key, dst := range map[string]interface{}{
+		"limits":             &limits,
+		"feature_limits":     &features,
+		"oom_disabled":       &limits.OOMDisabled,
+		"allocation":         &allocID,
+		"add_allocations":    &add,
+		"remove_allocations": &remove,
+	} {
+		if raw, ok := body[key]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, dst); err != nil {
+				writeValidation(w, []fieldError{{key, "array", fmt.Sprintf("The %s field is invalid.", key)}})
+				return
+			}
+		}
+	}
+	if raw, ok := body["oom_disabled"]; ok {
+		json.Unmarshal(raw, &limits.OOMDisabled)
+	}
+
+	for _, aid := range add {
+		a, ok := p.allocations[aid]
+		if !ok || a.node != s.NodeID || (a.server != 0 && a.server != s.ID) {
+			writeValidation(w, []fieldError{{"add_allocations", "exists", "The selected add allocations is invalid."}})
+			return
+		}
+	}
+	if a, ok := p.allocations[allocID]; !ok || (a.server != s.ID && !contains(add, allocID)) {
+		writeValidation(w, []fieldError{{"allocation", "exists", "The selected allocation is invalid."}})
+		return
+	}
+	if contains(remove, allocID) {
+		writeError(w, http.StatusBadRequest, "You are attempting to delete the default allocation for this server but there is no fallback allocation to use.")
+		return
+	}
+
+	for _, aid := range add {
+		p.allocations[aid].server, p.allocations[aid].Assigned = s.ID, true
+	}
+	for _, aid := range remove {
+		if a, ok := p.allocations[aid]; ok && a.server == s.ID {
+			a.server, a.Assigned = 0, false
+		}
+	}
+
+	s.Limits, s.FeatureLimits, s.Allocation = limits, features, allocID
+	s.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, item("server", attributes(s.AppServer)))
+}
+
+func contains(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Panel) updateServerStartup(w http.ResponseWriter, r *http.Request) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Startup     *string                `json:"startup"`
+		Environment map[string]interface{} `json:"environment"`
+		Egg         *int                   `json:"egg"`
+		Image       *string                `json:"image"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	egg := p.eggs[s.EggID]
+	if body.Egg != nil && *body.Egg != 0 {
+		if egg, ok = p.eggs[*body.Egg]; !ok {
+			writeValidation(w, []fieldError{{"egg", "exists", "The selected egg is invalid."}})
+			return
+		}
+	}
+	if body.Environment != nil {
+		if errs := environmentErrors(nil, egg, body.Environment); len(errs) > 0 {
+			writeValidation(w, errs)
+			return
+		}
+	}
+	s.EggID, s.NestID = egg.ID, egg.NestID
+	if body.Startup != nil && *body.Startup != "" {
+		s.Container.StartupCommand = *body.Startup
+	}
+	if body.Image != nil && *body.Image != "" {
+		s.Container.Image = *body.Image
+	}
+	for k, v := range body.Environment {
+		s.Container.Environment[k] = v
+	}
+	s.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, item("server", attributes(s.AppServer)))
+}
+
+func (p *Panel) suspendServer(w http.ResponseWriter, r *http.Request) {
+	if s, ok := p.server(w, r); ok {
+		s.Suspended, s.Status, s.state = true, "suspended", "offline"
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *Panel) unsuspendServer(w http.ResponseWriter, r *http.Request) {
+	if s, ok := p.server(w, r); ok {
+		s.Suspended, s.Status = false, ""
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *Panel) reinstallServer(w http.ResponseWriter, r *http.Request) {
+	if _, ok := p.server(w, r); ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *Panel) deleteServer(w http.ResponseWriter, r *http.Request) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return
+	}
+
+	for _, a := range p.allocations {
+		if a.server == s.ID {
+			a.server, a.Assigned = 0, false
+		}
+	}
+	for _, m := range p.mounts {
+		delete(m.servers, s.ID)
+	}
+	for _, c := range s.consoles {
+		c.conn.Close()
+	}
+	delete(p.servers, s.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Server databases
+
+func (p *Panel) databaseDoc(db *database, inc map[string]bool) document {
+	attrs := attributes(db.AppDatabase)
+	rel := document{}
+	if inc["password"] {
+		rel["password"] = item("database_password", document{"password": db.Password})
+	}
+	if inc["host"] {
+		rel["host"] = item("database_host", attributes(p.databaseHost))
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("server_database", attrs)
+}
+
+func (p *Panel) listServerDatabases(w http.ResponseWriter, r *http.Request) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return
+	}
+
+	inc := includes(r)
+	docs := make([]document, 0, len(s.databases))
+	for _, db := range s.databases {
+		docs = append(docs, p.databaseDoc(db, inc))
+	}
+	writeJSON(w, http.StatusOK, list(docs))
+}
+
+func (p *Panel) serverDatabase(w http.ResponseWriter, r *http.Request) (*server, int, bool) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+
+	id, _ := pathID(r, "db")
+	for i, db := range s.databases {
+		if db.ID == id {
+			return s, i, true
+		}
+	}
+	notFound(w)
+	return nil, 0, false
+}
+
+func (p *Panel) getServerDatabase(w http.ResponseWriter, r *http.Request) {
+	if s, i, ok := p.serverDatabase(w, r); ok {
+		writeJSON(w, http.StatusOK, p.databaseDoc(s.databases[i], includes(r)))
+	}
+}
+
+func (p *Panel) createServerDatabase(w http.ResponseWriter, r *http.Request) {
+	s, ok := p.server(w, r)
+	if !ok {
+		return
+	}
+
+	var body alligator.CreateDatabaseDescriptor
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var errs []fieldError
+	errs = required(errs, "database", body.Database != "")
+	errs = required(errs, "remote", body.Remote != "")
+	if body.Host != p.databaseHost.ID {
+		errs = append(errs, fieldError{"host", "exists", "The selected host is invalid."})
+	}
+	for _, db := range s.databases {
+		if db.Database == fmt.Sprintf("s%d_%s", s.ID, body.Database) {
+			errs = append(errs, fieldError{"database", "unique", "The database has already been taken."})
+		}
+	}
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	db := p.newDatabase(s, &alligator.AppDatabase{Database: body.Database, Remote: body.Remote})
+	writeJSON(w, http.StatusCreated, p.databaseDoc(db, includes(r)))
+}
+
+func (p *Panel) resetServerDatabasePassword(w http.ResponseWriter, r *http.Request) {
+	if s, i, ok := p.serverDatabase(w, r); ok {
+		s.databases[i].Password = randomString(24)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *Panel) deleteServerDatabase(w http.ResponseWriter, r *http.Request) {
+	if s, i, ok := p.serverDatabase(w, r); ok {
+		s.databases = append(s.databases[:i], s.databases[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Mounts
+
+func (p *Panel) mountDoc(m *mount, inc map[string]bool) document {
+	attrs := attributes(m.Mount)
+	rel := document{}
+	if inc["eggs"] {
+		var eggs []document
+		for _, id := range sortedKeys(m.eggs) {
+			if e, ok := p.eggs[id]; ok {
+				eggs = append(eggs, p.eggDoc(e, nil))
+			}
+		}
+		rel["eggs"] = list(eggs)
+	}
+	if inc["nodes"] {
+		rel["nodes"] = list(p.nodeDocs(func(n *alligator.Node) bool { return m.nodes[n.ID] }))
+	}
+	if inc["servers"] {
+		rel["servers"] = list(p.serverDocs(func(s *server) bool { return m.servers[s.ID] }))
+	}
+	if len(rel) > 0 {
+		attrs["relationships"] = rel
+	}
+
+	return item("mount", attrs)
+}
+
+func (p *Panel) listMounts(w http.ResponseWriter, r *http.Request) {
+	inc := includes(r)
+	var docs []document
+	for _, id := range sortedKeys(p.mounts) {
+		docs = append(docs, p.mountDoc(p.mounts[id], inc))
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) mount(w http.ResponseWriter, r *http.Request) (*mount, bool) {
+	id, _ := pathID(r, "id")
+	m, ok := p.mounts[id]
+	if !ok {
+		notFound(w)
+	}
+	return m, ok
+}
+
+func (p *Panel) getMount(w http.ResponseWriter, r *http.Request) {
+	if m, ok := p.mount(w, r); ok {
+		writeJSON(w, http.StatusOK, p.mountDoc(m, includes(r)))
+	}
+}
+
+func (p *Panel) validateMount(m *alligator.Mount) []fieldError {
+	var errs []fieldError
+	errs = required(errs, "name", m.Name != "")
+	errs = required(errs, "source", m.Source != "")
+	errs = required(errs, "target", m.Target != "")
+
+	for _, other := range p.mounts {
+		if other.ID != m.ID && m.Name != "" && other.Name == m.Name {
+			errs = append(errs, fieldError{"name", "unique", "The name has already been taken."})
+		}
+	}
+
+	return errs
+}
+
+func (p *Panel) createMount(w http.ResponseWriter, r *http.Request) {
+	var m alligator.Mount
+	if err := decode(r, &m); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	m.ID = 0
+
+	if errs := p.validateMount(&m); len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	m.ID = p.next("mount")
+	m.UUID = newUUID()
+	created := &mount{Mount: &m, eggs: make(map[int]bool), nodes: make(map[int]bool), servers: make(map[int]bool)}
+	p.mounts[m.ID] = created
+
+	writeJSON(w, http.StatusCreated, p.mountDoc(created, nil))
+}
+
+func (p *Panel) updateMount(w http.ResponseWriter, r *http.Request) {
+	m, ok := p.mount(w, r)
+	if !ok {
+		return
+	}
+
+	updated := *m.Mount
+	if err := decode(r, &updated); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated.ID, updated.UUID = m.ID, m.UUID
+
+	if errs := p.validateMount(&updated); len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	m.Mount = &updated
+
+	writeJSON(w, http.StatusOK, p.mountDoc(m, nil))
+}
+
+func (p *Panel) deleteMount(w http.ResponseWriter, r *http.Request) {
+	if m, ok := p.mount(w, r); ok {
+		delete(p.mounts, m.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// attachMount adds the ids sent under field to set, rejecting the request if one doesn't exist.
+func attachMount[V any](w http.ResponseWriter, r *http.Request, field string, set map[int]bool, known map[int]V) bool {
+	var body map[string][]int
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	ids := body[field]
+	if len(ids) == 0 {
+		writeValidation(w, required(nil, field, false))
+		return false
+	}
+	for _, id := range ids {
+		if _, ok := known[id]; !ok {
+			writeValidation(w, []fieldError{{field, "exists", fmt.Sprintf("The selected %s is invalid.", field)}})
+			return false
+		}
+	}
+
+	for _, id := range ids {
+		set[id] = true
+	}
+	return true
+}
+
+func (p *Panel) attachMountEggs(w http.ResponseWriter, r *http.Request) {
+	if m, ok := p.mount(w, r); ok && attachMount(w, r, "eggs", m.eggs, p.eggs) {
+		writeJSON(w, http.StatusOK, p.mountDoc(m, nil))
+	}
+}
+
+func (p *Panel) detachMountEgg(w http.ResponseWriter, r *http.Request) {
+	if m, ok := p.mount(w, r); ok {
+		id, _ := pathID(r, "egg")
+		delete(m.eggs, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *Panel) attachMountNodes(w http.ResponseWriter, r *http.Request) {
+	if m, ok := p.mount(w, r); ok && attachMount(w, r, "nodes", m.nodes, p.nodes) {
+		writeJSON(w, http.StatusOK, p.mountDoc(m, nil))
+	}
+}
+
+func (p *Panel) detachMountNode(w http.ResponseWriter, r *http.Request) {
+	if m, ok := p.mount(w, r); ok {
+		id, _ := pathID(r, "node")
+		delete(m.nodes, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
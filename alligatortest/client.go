@@ -0,0 +1,1631 @@
+package alligatortest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha1"
+	"fmt"
+	"github.com/m41denx/alligator"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (p *Panel) clientRoutes(mux *http.ServeMux) {
+	account := map[string]func(http.ResponseWriter, *http.Request, *alligator.User){
+		"GET ":             p.listClientServers,
+		"GET /account":     p.getAccount,
+		"GET /permissions": p.getPermissions,
+	}
+	servers := map[string]func(http.ResponseWriter, *http.Request, *server){
+		"GET ":                                           p.getClientServer,
+		"GET /websocket":                                 p.getWebSocket,
+		"GET /resources":                                 p.getResources,
+		"POST /command":                                  p.sendCommand,
+		"POST /power":                                    p.setPowerState,
+		"GET /databases":                                 p.listDatabases,
+		"POST /databases":                                p.createDatabase,
+		"POST /databases/{db}/rotate-password":           p.rotateDatabasePassword,
+		"DELETE /databases/{db}":                         p.deleteDatabase,
+		"GET /files/list":                                p.listFiles,
+		"GET /files/contents":                            p.getFileContents,
+		"GET /files/download":                            p.downloadFile,
+		"GET /files/upload":                              p.uploadFile,
+		"PUT /files/rename":                              p.renameFiles,
+		"POST /files/copy":                               p.copyFile,
+		"POST /files/write":                              p.writeFile,
+		"POST /files/compress":                           p.compressFiles,
+		"POST /files/decompress":                         p.decompressFile,
+		"POST /files/delete":                             p.deleteFiles,
+		"POST /files/create-folder":                      p.createFolder,
+		"POST /files/chmod":                              p.chmodFiles,
+		"POST /files/pull":                               p.pullFile,
+		"GET /backups":                                   p.listBackups,
+		"POST /backups":                                  p.createBackup,
+		"GET /backups/{backup}":                          p.getBackup,
+		"GET /backups/{backup}/download":                 p.downloadBackup,
+		"POST /backups/{backup}/restore":                 p.restoreBackup,
+		"POST /backups/{backup}/lock":                    p.toggleBackupLock,
+		"DELETE /backups/{backup}":                       p.deleteBackup,
+		"GET /schedules":                                 p.listSchedules,
+		"POST /schedules":                                p.createSchedule,
+		"GET /schedules/{schedule}":                      p.getSchedule,
+		"POST /schedules/{schedule}":                     p.updateSchedule,
+		"POST /schedules/{schedule}/execute":             p.executeSchedule,
+		"DELETE /schedules/{schedule}":                   p.deleteSchedule,
+		"POST /schedules/{schedule}/tasks":               p.createTask,
+		"POST /schedules/{schedule}/tasks/{task}":        p.updateTask,
+		"DELETE /schedules/{schedule}/tasks/{task}":      p.deleteTask,
+		"GET /users":                                     p.listSubusers,
+		"POST /users":                                    p.createSubuser,
+		"GET /users/{user}":                              p.getSubuser,
+		"POST /users/{user}":                             p.updateSubuser,
+		"DELETE /users/{user}":                           p.deleteSubuser,
+		"GET /network/allocations":                       p.listClientAllocations,
+		"POST /network/allocations":                      p.assignAllocation,
+		"POST /network/allocations/{allocation}":         p.updateAllocationNotes,
+		"POST /network/allocations/{allocation}/primary": p.setPrimaryAllocation,
+		"DELETE /network/allocations/{allocation}":       p.deleteClientAllocation,
+	}
+
+	for route, h := range account {
+		method, path, _ := strings.Cut(route, " ")
+		mux.HandleFunc(method+" /api/client"+path, p.client(h))
+	}
+	for route, h := range servers {
+		method, path, _ := strings.Cut(route, " ")
+		mux.HandleFunc(method+" /api/client/servers/{server}"+path, p.client(p.clientServer(h)))
+	}
+}
+
+func (p *Panel) client(h func(http.ResponseWriter, *http.Request, *alligator.User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		u, ok := p.users[p.clientKeys[key]]
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "Unauthenticated.")
+			return
+		}
+
+		h(w, r, u)
+	}
+}
+
+// clientServer resolves the {server} identifier, only the owner, its subusers and root admins can access it.
+func (p *Panel) clientServer(h func(http.ResponseWriter, *http.Request, *server)) func(http.ResponseWriter, *http.Request, *alligator.User) {
+	return func(w http.ResponseWriter, r *http.Request, u *alligator.User) {
+		s := p.serverByIdentifier(r.PathValue("server"))
+		if s == nil || (s.UserID != u.ID && s.subuser(u.ID) == nil && !u.RootAdmin) {
+			notFound(w)
+			return
+		}
+
+		h(w, r, s)
+	}
+}
+
+func (p *Panel) clientServerDoc(s *server, u *alligator.User) document {
+	cs := alligator.ClientServer{
+		ServerOwner:   s.UserID == u.ID,
+		Identifier:    s.Identifier,
+		UUID:          s.UUID,
+		InternalID:    s.ID,
+		Name:          s.Name,
+		Description:   s.Description,
+		Limits:        s.Limits,
+		Invocation:    s.Container.StartupCommand,
+		DockerImage:   s.Container.Image,
+		EggFeatures:   []string{},
+		FeatureLimits: s.FeatureLimits,
+		Status:        s.Status,
+		Suspended:     s.Suspended,
+		Installing:    s.Container.Installed == 0,
+	}
+	if n, ok := p.nodes[s.NodeID]; ok {
+		cs.Node = n.Name
+		cs.SFTP.IP = n.FQDN
+		cs.SFTP.Port = int64(n.DaemonSftp)
+	}
+
+	allocations := make([]document, 0)
+	for _, a := range p.serverAllocations(s) {
+		allocations = append(allocations, clientAllocationDoc(s, a))
+	}
+	attrs := attributes(cs)
+	attrs["relationships"] = document{"allocations": list(allocations)}
+	return item("server", attrs)
+}
+
+func (p *Panel) listClientServers(w http.ResponseWriter, r *http.Request, u *alligator.User) {
+	all := u.RootAdmin && strings.HasPrefix(r.URL.Query().Get("type"), "admin")
+
+	var docs []document
+	for _, id := range sortedKeys(p.servers) {
+		if s := p.servers[id]; s.UserID == u.ID || s.subuser(u.ID) != nil || all {
+			docs = append(docs, p.clientServerDoc(s, u))
+		}
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) getAccount(w http.ResponseWriter, r *http.Request, u *alligator.User) {
+	writeJSON(w, http.StatusOK, item("user", alligator.Account{
+		ID:        u.ID,
+		Admin:     u.RootAdmin,
+		Username:  u.Username,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Language:  u.Language,
+	}))
+}
+
+func (p *Panel) getClientServer(w http.ResponseWriter, r *http.Request, s *server) {
+	writeJSON(w, http.StatusOK, p.clientServerDoc(s, p.requester(r)))
+}
+
+// requester returns the user the client API key belongs to.
+func (p *Panel) requester(r *http.Request) *alligator.User {
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return p.users[p.clientKeys[key]]
+}
+
+func (p *Panel) getWebSocket(w http.ResponseWriter, r *http.Request, s *server) {
+	token := randomString(64)
+	p.socketTokens[token] = s.ID
+
+	writeJSON(w, http.StatusOK, document{"data": alligator.WebSocketAuth{
+		Socket: p.socketURL(s),
+		Token:  token,
+	}})
+}
+
+func (p *Panel) getResources(w http.ResponseWriter, r *http.Request, s *server) {
+	res := alligator.Resources{State: s.state, Suspended: s.Suspended}
+	if s.state == "running" {
+		res.Usage.MemoryBytes = s.Limits.Memory << 19 // Half of the limit in MiB
+		res.Usage.CPUAbsolute = 12.5
+		res.Usage.Uptime = time.Since(s.started).Milliseconds()
+	}
+	for _, f := range s.files {
+		res.Usage.DiskBytes += int64(len(f.data))
+	}
+
+	writeJSON(w, http.StatusOK, item("stats", res))
+}
+
+func (p *Panel) sendCommand(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Command string `json:"command"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body.Command == "" {
+		writeValidation(w, required(nil, "command", false))
+		return
+	}
+	if s.Suspended {
+		writeError(w, http.StatusConflict, "This server is currently suspended and the functionality requested is unavailable.")
+		return
+	}
+	if s.state != "running" {
+		writeError(w, http.StatusBadGateway, "Server must be online in order to send commands.")
+		return
+	}
+
+	s.commands = append(s.commands, body.Command)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) setPowerState(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Signal string `json:"signal"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if s.Suspended {
+		writeError(w, http.StatusConflict, "This server is currently suspended and the functionality requested is unavailable.")
+		return
+	}
+
+	if !p.power(s, body.Signal) {
+		writeValidation(w, []fieldError{{"signal", "in", "The selected signal is invalid."}})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Databases
+
+func (p *Panel) clientDatabaseDoc(db *database, password bool) document {
+	cd := alligator.ClientDatabase{
+		ID:              db.hashid,
+		Name:            db.Database,
+		Username:        db.Username,
+		ConnectionsFrom: db.Remote,
+		MaxConnections:  db.MaxConnections,
+	}
+	cd.Host.Address = p.databaseHost.Host
+	cd.Host.Port = p.databaseHost.Port
+
+	attrs := attributes(cd)
+	if password {
+		attrs["relationships"] = document{
+			"password": item("database_password", document{"password": db.Password}),
+		}
+	}
+	return item("server_database", attrs)
+}
+
+func (p *Panel) listDatabases(w http.ResponseWriter, r *http.Request, s *server) {
+	docs := make([]document, 0, len(s.databases))
+	for _, db := range s.databases {
+		docs = append(docs, p.clientDatabaseDoc(db, includes(r)["password"]))
+	}
+	writeJSON(w, http.StatusOK, list(docs))
+}
+
+func (p *Panel) createDatabase(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Remote   string `json:"remote"`
+		Database string `json:"database"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var errs []fieldError
+	errs = required(errs, "database", body.Database != "")
+	errs = required(errs, "remote", body.Remote != "")
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+	if len(s.databases) >= s.FeatureLimits.Databases {
+		writeError(w, http.StatusBadRequest, "Cannot create additional databases on this server: limit has been reached.")
+		return
+	}
+
+	db := p.newDatabase(s, &alligator.AppDatabase{Database: body.Database, Remote: body.Remote})
+	writeJSON(w, http.StatusOK, p.clientDatabaseDoc(db, true))
+}
+
+func (p *Panel) clientDatabase(w http.ResponseWriter, r *http.Request, s *server) (int, bool) {
+	for i, db := range s.databases {
+		if db.hashid == r.PathValue("db") {
+			return i, true
+		}
+	}
+	notFound(w)
+	return 0, false
+}
+
+func (p *Panel) rotateDatabasePassword(w http.ResponseWriter, r *http.Request, s *server) {
+	if i, ok := p.clientDatabase(w, r, s); ok {
+		s.databases[i].Password = randomString(24)
+		writeJSON(w, http.StatusOK, p.clientDatabaseDoc(s.databases[i], true))
+	}
+}
+
+func (p *Panel) deleteDatabase(w http.ResponseWriter, r *http.Request, s *server) {
+	if i, ok := p.clientDatabase(w, r, s); ok {
+		s.databases = append(s.databases[:i], s.databases[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Files
+
+func fileDoc(name string, f *file) document {
+	mode := fs.FileMode(f.mode)
+	mimetype := "inode/directory"
+	if f.dir {
+		mode |= fs.ModeDir
+	} else if mimetype = mime.TypeByExtension(path.Ext(name)); mimetype == "" {
+		mimetype = http.DetectContentType(f.data)
+	}
+
+	return item("file_object", document{
+		"name":        path.Base(name),
+		"mode":        mode.String(),
+		"mode_bits":   strconv.FormatUint(uint64(f.mode), 8),
+		"size":        len(f.data),
+		"is_file":     !f.dir,
+		"is_symlink":  false,
+		"mimetype":    mimetype,
+		"created_at":  f.modified,
+		"modified_at": f.modified,
+	})
+}
+
+// children returns the paths of the files directly inside dir, sorted.
+func (s *server) children(dir string) []string {
+	var names []string
+	for name := range s.files {
+		if name != "/" && path.Dir(name) == dir {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tree returns name and every path below it.
+func (s *server) tree(name string) []string {
+	var names []string
+	for n := range s.files {
+		if n == name || strings.HasPrefix(n, strings.TrimSuffix(name, "/")+"/") {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Panel) listFiles(w http.ResponseWriter, r *http.Request, s *server) {
+	dir := cleanPath(r.URL.Query().Get("directory"))
+	if f, ok := s.files[dir]; !ok || !f.dir {
+		notFound(w)
+		return
+	}
+
+	var docs []document
+	for _, name := range s.children(dir) {
+		docs = append(docs, fileDoc(name, s.files[name]))
+	}
+	writeJSON(w, http.StatusOK, list(docs))
+}
+
+func (p *Panel) getFileContents(w http.ResponseWriter, r *http.Request, s *server) {
+	f, ok := s.files[cleanPath(r.URL.Query().Get("file"))]
+	if !ok {
+		notFound(w)
+		return
+	}
+	if f.dir {
+		writeError(w, http.StatusBadRequest, "Cannot perform that action: file is a directory.")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(f.data)
+}
+
+func (p *Panel) transferURL(s *server, name string, upload bool) string {
+	token := randomString(32)
+	p.transfers[token] = transfer{server: s.ID, path: name, upload: upload}
+
+	kind := "download"
+	if upload {
+		kind = "upload"
+	}
+	return fmt.Sprintf("%s/_transfer/%s?token=%s", p.URL, kind, token)
+}
+
+func (p *Panel) downloadFile(w http.ResponseWriter, r *http.Request, s *server) {
+	name := cleanPath(r.URL.Query().Get("file"))
+	f, ok := s.files[name]
+	if !ok || f.dir {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item("signed_url", document{"url": p.transferURL(s, name, false)}))
+}
+
+func (p *Panel) uploadFile(w http.ResponseWriter, r *http.Request, s *server) {
+	writeJSON(w, http.StatusOK, item("signed_url", document{"url": p.transferURL(s, "/", true)}))
+}
+
+func (p *Panel) renameFiles(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Root  string `json:"root"`
+		Files []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"files"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, rename := range body.Files {
+		from, to := cleanPath(path.Join(body.Root, rename.From)), cleanPath(path.Join(body.Root, rename.To))
+		if _, ok := s.files[from]; !ok {
+			notFound(w)
+			return
+		}
+		if _, ok := s.files[to]; ok {
+			writeError(w, http.StatusBadRequest, "Cannot move or rename file, destination already exists.")
+			return
+		}
+
+		for _, name := range s.tree(from) {
+			s.files[to+strings.TrimPrefix(name, from)] = s.files[name]
+			delete(s.files, name)
+		}
+		s.mkdirAll(path.Dir(to))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) copyFile(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Location string `json:"location"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name := cleanPath(body.Location)
+	f, ok := s.files[name]
+	if !ok {
+		notFound(w)
+		return
+	}
+	if f.dir {
+		writeError(w, http.StatusBadRequest, "Cannot perform that action: file is a directory.")
+		return
+	}
+
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	dst := base + " copy" + ext
+	for i := 2; s.files[dst] != nil; i++ {
+		dst = fmt.Sprintf("%s copy %d%s", base, i, ext)
+	}
+	s.writeFile(dst, append([]byte(nil), f.data...))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) writeFile(w http.ResponseWriter, r *http.Request, s *server) {
+	name := cleanPath(r.URL.Query().Get("file"))
+	if f, ok := s.files[name]; ok && f.dir {
+		writeError(w, http.StatusBadRequest, "Cannot perform that action: file is a directory.")
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.writeFile(name, data)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) compressFiles(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Root  string   `json:"root"`
+		Files []string `json:"files"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	root := cleanPath(body.Root)
+
+	var names []string
+	for _, f := range body.Files {
+		from := cleanPath(path.Join(root, f))
+		if _, ok := s.files[from]; !ok {
+			notFound(w)
+			return
+		}
+		names = append(names, s.tree(from)...)
+	}
+
+	name := path.Join(root, fmt.Sprintf("archive-%s.tar.gz", time.Now().Format("2006-01-02T150405")))
+	s.writeFile(name, archive(s.files, names, root))
+
+	writeJSON(w, http.StatusOK, fileDoc(name, s.files[name]))
+}
+
+// archive builds a .tar.gz of the named files, with paths relative to root.
+func archive(files map[string]*file, names []string, root string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		f := files[name]
+		hdr := &tar.Header{
+			Name:    strings.TrimPrefix(strings.TrimPrefix(name, root), "/"),
+			Mode:    int64(f.mode),
+			Size:    int64(len(f.data)),
+			ModTime: f.modified,
+		}
+		if f.dir {
+			hdr.Typeflag, hdr.Name = tar.TypeDir, hdr.Name+"/"
+		}
+		tw.WriteHeader(hdr)
+		tw.Write(f.data)
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func (p *Panel) decompressFile(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Root string `json:"root"`
+		File string `json:"file"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	root := cleanPath(body.Root)
+
+	f, ok := s.files[cleanPath(path.Join(root, body.File))]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(f.data))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "The archive could not be read, only .tar.gz archives are supported.")
+		return
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		name := cleanPath(path.Join(root, hdr.Name))
+		if hdr.Typeflag == tar.TypeDir {
+			s.mkdirAll(name)
+			continue
+		}
+		data, _ := io.ReadAll(tr)
+		s.writeFile(name, data)
+		s.files[name].mode = uint32(hdr.Mode)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) deleteFiles(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Root  string   `json:"root"`
+		Files []string `json:"files"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, f := range body.Files {
+		name := cleanPath(path.Join(body.Root, f))
+		if name == "/" {
+			writeError(w, http.StatusBadRequest, "Cannot delete the root directory.")
+			return
+		}
+		for _, n := range s.tree(name) {
+			delete(s.files, n)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) createFolder(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Root string `json:"root"`
+		Name string `json:"name"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name := cleanPath(path.Join(body.Root, body.Name))
+	if _, ok := s.files[name]; ok {
+		writeError(w, http.StatusBadRequest, "Cannot create folder, a file with that name already exists.")
+		return
+	}
+	s.mkdirAll(name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) chmodFiles(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Root  string `json:"root"`
+		Files []struct {
+			File string `json:"file"`
+			Mode uint32 `json:"mode"`
+		} `json:"files"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, f := range body.Files {
+		file, ok := s.files[cleanPath(path.Join(body.Root, f.File))]
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		// Modes are sent as their octal digits, e.g. 755
+		mode, err := strconv.ParseUint(strconv.FormatUint(uint64(f.Mode), 10), 8, 32)
+		if err != nil {
+			writeValidation(w, []fieldError{{"files.mode", "integer", "The files.mode must be a valid file mode."}})
+			return
+		}
+		file.mode = uint32(mode)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pullFile creates an empty file where the remote file would be downloaded to,
+// the fake panel never makes outgoing requests.
+func (p *Panel) pullFile(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		URL       string `json:"url"`
+		Directory string `json:"directory"`
+		Filename  string `json:"filename"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body.URL == "" {
+		writeValidation(w, required(nil, "url", false))
+		return
+	}
+
+	name := body.Filename
+	if name == "" {
+		name = path.Base(strings.SplitN(body.URL, "?", 2)[0])
+	}
+	s.writeFile(path.Join(body.Directory, name), nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Backups
+
+func backupDoc(b *backup) document {
+	return item("backup", attributes(b.Backup))
+}
+
+func (p *Panel) backup(w http.ResponseWriter, r *http.Request, s *server) (*backup, bool) {
+	for _, b := range s.backups {
+		if b.UUID == r.PathValue("backup") {
+			return b, true
+		}
+	}
+	notFound(w)
+	return nil, false
+}
+
+func (p *Panel) listBackups(w http.ResponseWriter, r *http.Request, s *server) {
+	docs := make([]document, 0, len(s.backups))
+	for _, b := range s.backups {
+		docs = append(docs, backupDoc(b))
+	}
+	writeList(w, r, docs)
+}
+
+func (p *Panel) createBackup(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Name     string `json:"name"`
+		Ignored  string `json:"ignored"`
+		IsLocked bool   `json:"is_locked"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if detail := backupLimit(s); detail != "" {
+		writeError(w, http.StatusBadRequest, detail)
+		return
+	}
+
+	b := p.newBackup(s, body.Name, body.Ignored, body.IsLocked)
+	writeJSON(w, http.StatusOK, backupDoc(b))
+}
+
+// backupLimit returns why another backup can't be created, or an empty string.
+func backupLimit(s *server) string {
+	switch limit := s.FeatureLimits.Backups; {
+	case limit == 0:
+		return "Backups are disabled for this server."
+	case len(s.backups) >= limit:
+		return fmt.Sprintf("Only %d backups may be created for this server.", limit)
+	}
+	return ""
+}
+
+// newBackup completes right away, with a copy of the files that weren't ignored.
+func (p *Panel) newBackup(s *server, name, ignoredFiles string, locked bool) *backup {
+	ignored := []string{}
+	for _, line := range strings.Split(ignoredFiles, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			ignored = append(ignored, line)
+		}
+	}
+
+	files := make(map[string]*file)
+	var names []string
+	for name, f := range s.files {
+		if name != "/" && !ignoredFile(name, ignored) {
+			copied := *f
+			files[name] = &copied
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	data := archive(files, names, "/")
+
+	b := &backup{Backup: &alligator.Backup{
+		UUID:         newUUID(),
+		Name:         name,
+		IgnoredFiles: ignored,
+		Checksum:     fmt.Sprintf("sha1:%x", sha1.Sum(data)),
+		Bytes:        int64(len(data)),
+		IsSuccessful: true,
+		IsLocked:     locked,
+		CreatedAt:    now(),
+	}, files: files, data: data}
+	b.CompletedAt = b.CreatedAt
+	if b.Name == "" {
+		b.Name = "Backup at " + b.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	s.backups = append(s.backups, b)
+	return b
+}
+
+// ignoredFile matches a path against the ignored patterns, either the whole path or its base name.
+func ignoredFile(name string, ignored []string) bool {
+	for _, pattern := range ignored {
+		clean := cleanPath(pattern)
+		if name == clean || strings.HasPrefix(name, clean+"/") {
+			return true
+		}
+		if ok, _ := path.Match(clean, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Panel) getBackup(w http.ResponseWriter, r *http.Request, s *server) {
+	if b, ok := p.backup(w, r, s); ok {
+		writeJSON(w, http.StatusOK, backupDoc(b))
+	}
+}
+
+func (p *Panel) downloadBackup(w http.ResponseWriter, r *http.Request, s *server) {
+	b, ok := p.backup(w, r, s)
+	if !ok {
+		return
+	}
+
+	token := randomString(32)
+	p.transfers[token] = transfer{server: s.ID, path: b.UUID}
+	url := fmt.Sprintf("%s/_transfer/backup?token=%s", p.URL, token)
+	writeJSON(w, http.StatusOK, item("signed_url", document{"url": url}))
+}
+
+func (p *Panel) restoreBackup(w http.ResponseWriter, r *http.Request, s *server) {
+	b, ok := p.backup(w, r, s)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Truncate bool `json:"truncate"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !b.IsSuccessful {
+		writeError(w, http.StatusBadRequest, "This backup cannot be restored at this time: not completed or failed.")
+		return
+	}
+
+	if body.Truncate {
+		s.files = map[string]*file{"/": s.files["/"]}
+	}
+	for name, f := range b.files {
+		copied := *f
+		s.mkdirAll(path.Dir(name))
+		s.files[name] = &copied
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Panel) toggleBackupLock(w http.ResponseWriter, r *http.Request, s *server) {
+	if b, ok := p.backup(w, r, s); ok {
+		b.IsLocked = !b.IsLocked
+		writeJSON(w, http.StatusOK, backupDoc(b))
+	}
+}
+
+func (p *Panel) deleteBackup(w http.ResponseWriter, r *http.Request, s *server) {
+	b, ok := p.backup(w, r, s)
+	if !ok {
+		return
+	}
+	if b.IsLocked {
+		writeError(w, http.StatusBadRequest, "Cannot delete a backup that is marked as locked before unlocking it.")
+		return
+	}
+
+	s.backups = slices.DeleteFunc(s.backups, func(other *backup) bool { return other == b })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Schedules
+
+// maxTasks is the panel's default limit of tasks per schedule.
+const maxTasks = 10
+
+func scheduleDoc(sc *alligator.Schedule) document {
+	tasks := make([]document, 0, len(sc.Tasks))
+	for _, t := range sc.Tasks {
+		tasks = append(tasks, item("schedule_task", t))
+	}
+	attrs := attributes(sc)
+	attrs["relationships"] = document{"tasks": list(tasks)}
+	return item("server_schedule", attrs)
+}
+
+func (p *Panel) schedule(w http.ResponseWriter, r *http.Request, s *server) (*alligator.Schedule, bool) {
+	id, _ := strconv.Atoi(r.PathValue("schedule"))
+	for _, sc := range s.schedules {
+		if sc.ID == id {
+			return sc, true
+		}
+	}
+	notFound(w)
+	return nil, false
+}
+
+func (p *Panel) listSchedules(w http.ResponseWriter, r *http.Request, s *server) {
+	docs := make([]document, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		docs = append(docs, scheduleDoc(sc))
+	}
+	writeJSON(w, http.StatusOK, list(docs))
+}
+
+// decodeSchedule reads and validates the body of a create or update request.
+func decodeSchedule(w http.ResponseWriter, r *http.Request) (*alligator.ScheduleDescriptor, bool) {
+	var body alligator.ScheduleDescriptor
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	var errs []fieldError
+	errs = required(errs, "name", body.Name != "")
+	errs = required(errs, "minute", body.Minute != "")
+	errs = required(errs, "hour", body.Hour != "")
+	errs = required(errs, "day_of_month", body.DayOfMonth != "")
+	errs = required(errs, "month", body.Month != "")
+	errs = required(errs, "day_of_week", body.DayOfWeek != "")
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return nil, false
+	}
+	if _, err := body.Next(time.Now()); err != nil {
+		writeError(w, http.StatusBadRequest, "The cron data provided does not evaluate to a valid expression.")
+		return nil, false
+	}
+	return &body, true
+}
+
+func applySchedule(sc *alligator.Schedule, body *alligator.ScheduleDescriptor) {
+	sc.Name, sc.Cron = body.Name, body.ScheduleCron
+	sc.IsActive, sc.OnlyWhenOnline = body.IsActive, body.OnlyWhenOnline
+	sc.UpdatedAt = now()
+	next, _ := sc.Cron.Next(time.Now().UTC())
+	sc.NextRunAt = &next
+}
+
+func (p *Panel) createSchedule(w http.ResponseWriter, r *http.Request, s *server) {
+	body, ok := decodeSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	sc := &alligator.Schedule{ID: p.next("schedule"), CreatedAt: now(), Tasks: []*alligator.Task{}}
+	applySchedule(sc, body)
+	s.schedules = append(s.schedules, sc)
+
+	writeJSON(w, http.StatusOK, scheduleDoc(sc))
+}
+
+func (p *Panel) getSchedule(w http.ResponseWriter, r *http.Request, s *server) {
+	if sc, ok := p.schedule(w, r, s); ok {
+		writeJSON(w, http.StatusOK, scheduleDoc(sc))
+	}
+}
+
+func (p *Panel) updateSchedule(w http.ResponseWriter, r *http.Request, s *server) {
+	sc, ok := p.schedule(w, r, s)
+	if !ok {
+		return
+	}
+	body, ok := decodeSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	applySchedule(sc, body)
+	if !sc.IsActive {
+		sc.IsProcessing = false
+	}
+	writeJSON(w, http.StatusOK, scheduleDoc(sc))
+}
+
+// executeSchedule runs the tasks right away, ignoring their time offsets.
+func (p *Panel) executeSchedule(w http.ResponseWriter, r *http.Request, s *server) {
+	sc, ok := p.schedule(w, r, s)
+	if !ok {
+		return
+	}
+	if len(sc.Tasks) == 0 {
+		writeError(w, http.StatusBadRequest, "Cannot process schedule for task execution: no tasks are registered.")
+		return
+	}
+
+	for _, t := range sc.Tasks {
+		if sc.OnlyWhenOnline && s.state != "running" {
+			break
+		}
+		if !p.runTask(s, t) && !t.ContinueOnFailure {
+			break
+		}
+	}
+	sc.LastRunAt = now()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// runTask performs a task the way Wings would, it reports whether the task succeeded.
+func (p *Panel) runTask(s *server, t *alligator.Task) bool {
+	switch t.Action {
+	case alligator.TaskActionCommand:
+		if s.state != "running" {
+			return false
+		}
+		s.commands = append(s.commands, t.Payload)
+		return true
+	case alligator.TaskActionPower:
+		return p.power(s, t.Payload)
+	case alligator.TaskActionBackup:
+		if backupLimit(s) != "" {
+			return false
+		}
+		p.newBackup(s, "", t.Payload, false)
+		return true
+	}
+	return false
+}
+
+func (p *Panel) deleteSchedule(w http.ResponseWriter, r *http.Request, s *server) {
+	if sc, ok := p.schedule(w, r, s); ok {
+		s.schedules = slices.DeleteFunc(s.schedules, func(other *alligator.Schedule) bool { return other == sc })
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *Panel) task(w http.ResponseWriter, r *http.Request, sc *alligator.Schedule) (*alligator.Task, bool) {
+	id, _ := strconv.Atoi(r.PathValue("task"))
+	for _, t := range sc.Tasks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	notFound(w)
+	return nil, false
+}
+
+// decodeTask reads and validates the body of a create or update request.
+func decodeTask(w http.ResponseWriter, r *http.Request, s *server) (*alligator.TaskDescriptor, bool) {
+	var body alligator.TaskDescriptor
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	var errs []fieldError
+	switch body.Action {
+	case alligator.TaskActionCommand, alligator.TaskActionPower:
+		errs = required(errs, "payload", body.Payload != "")
+	case alligator.TaskActionBackup:
+	case "":
+		errs = required(errs, "action", false)
+	default:
+		errs = append(errs, fieldError{"action", "in", "The selected action is invalid."})
+	}
+	if body.TimeOffset < 0 || body.TimeOffset > 900 {
+		errs = append(errs, fieldError{"time_offset", "between", "The time offset must be between 0 and 900."})
+	}
+	if body.SequenceID < 0 {
+		errs = append(errs, fieldError{"sequence_id", "min", "The sequence id must be at least 1."})
+	}
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return nil, false
+	}
+	if body.Action == alligator.TaskActionBackup && s.FeatureLimits.Backups == 0 {
+		writeError(w, http.StatusBadRequest, "A backup task cannot be created when the server's backup limit is set to 0.")
+		return nil, false
+	}
+	return &body, true
+}
+
+// sequenceTasks moves t to the requested position and renumbers the tasks from 1.
+func sequenceTasks(sc *alligator.Schedule, t *alligator.Task, sequence int) {
+	tasks := slices.DeleteFunc(sc.Tasks, func(other *alligator.Task) bool { return other == t })
+	if sequence < 1 || sequence > len(tasks) {
+		sequence = len(tasks) + 1
+	}
+	sc.Tasks = slices.Insert(tasks, sequence-1, t)
+	for i, task := range sc.Tasks {
+		task.SequenceID = i + 1
+	}
+}
+
+func (p *Panel) createTask(w http.ResponseWriter, r *http.Request, s *server) {
+	sc, ok := p.schedule(w, r, s)
+	if !ok {
+		return
+	}
+	body, ok := decodeTask(w, r, s)
+	if !ok {
+		return
+	}
+	if len(sc.Tasks) >= maxTasks {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Schedules may not have more than %d tasks associated with them. Creating this task would put this schedule over the limit.", maxTasks))
+		return
+	}
+
+	t := &alligator.Task{
+		ID:                p.next("task"),
+		Action:            body.Action,
+		Payload:           body.Payload,
+		TimeOffset:        body.TimeOffset,
+		ContinueOnFailure: body.ContinueOnFailure,
+		CreatedAt:         now(),
+	}
+	t.UpdatedAt = t.CreatedAt
+	sequenceTasks(sc, t, body.SequenceID)
+
+	writeJSON(w, http.StatusOK, item("schedule_task", t))
+}
+
+func (p *Panel) updateTask(w http.ResponseWriter, r *http.Request, s *server) {
+	sc, ok := p.schedule(w, r, s)
+	if !ok {
+		return
+	}
+	t, ok := p.task(w, r, sc)
+	if !ok {
+		return
+	}
+	body, ok := decodeTask(w, r, s)
+	if !ok {
+		return
+	}
+
+	t.Action, t.Payload = body.Action, body.Payload
+	t.TimeOffset, t.ContinueOnFailure = body.TimeOffset, body.ContinueOnFailure
+	t.UpdatedAt = now()
+	if body.SequenceID > 0 {
+		sequenceTasks(sc, t, body.SequenceID)
+	}
+
+	writeJSON(w, http.StatusOK, item("schedule_task", t))
+}
+
+func (p *Panel) deleteTask(w http.ResponseWriter, r *http.Request, s *server) {
+	sc, ok := p.schedule(w, r, s)
+	if !ok {
+		return
+	}
+	t, ok := p.task(w, r, sc)
+	if !ok {
+		return
+	}
+
+	sc.Tasks = slices.DeleteFunc(sc.Tasks, func(other *alligator.Task) bool { return other == t })
+	for i, task := range sc.Tasks {
+		task.SequenceID = i + 1
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Subusers
+
+// permissionGroups is the panel's permission catalogue, returned by /api/client/permissions.
+var permissionGroups = map[string]alligator.PermissionGroup{
+	"websocket": {
+		Description: "Allows the user to connect to the server websocket, giving them access to view console output and realtime server stats.",
+		Keys: map[string]string{
+			"connect": "Allows a user to connect to the websocket instance for a server to stream the console.",
+		},
+	},
+	"control": {
+		Description: "Permissions that control a user's ability to control the power state of a server, or send commands.",
+		Keys: map[string]string{
+			"console": "Allows a user to send commands to the server instance via the console.",
+			"start":   "Allows a user to start the server if it is stopped.",
+			"stop":    "Allows a user to stop a server if it is running.",
+			"restart": "Allows a user to perform a server restart. This allows them to start the server if it is offline, but not put the server in a completely stopped state.",
+		},
+	},
+	"user": {
+		Description: "Permissions that allow a user to manage other subusers on a server. They will never be able to edit their own account, or assign permissions they do not have themselves.",
+		Keys: map[string]string{
+			"create": "Allows a user to create new subusers for the server.",
+			"read":   "Allows the user to view subusers and their permissions for the server.",
+			"update": "Allows a user to modify other subusers.",
+			"delete": "Allows a user to delete a subuser from the server.",
+		},
+	},
+	"file": {
+		Description: "Permissions that control a user's ability to modify the filesystem for this server.",
+		Keys: map[string]string{
+			"create":       "Allows a user to create additional files and folders via the Panel or direct upload.",
+			"read":         "Allows a user to view the contents of a directory, but not view the contents of or download files.",
+			"read-content": "Allows a user to view the contents of a given file. This will also allow the user to download files.",
+			"update":       "Allows a user to update the contents of an existing file or directory.",
+			"delete":       "Allows a user to delete files or directories.",
+			"archive":      "Allows a user to archive the contents of a directory as well as decompress existing archives on the system.",
+			"sftp":         "Allows a user to connect to SFTP and manage server files using the other assigned file permissions.",
+		},
+	},
+	"backup": {
+		Description: "Permissions that control a user's ability to generate and manage server backups.",
+		Keys: map[string]string{
+			"create":   "Allows a user to create new backups for this server.",
+			"read":     "Allows a user to view all backups that exist for this server.",
+			"delete":   "Allows a user to remove backups from the system.",
+			"download": "Allows a user to download a backup for the server. Danger: this allows a user to access all files for the server in the backup.",
+			"restore":  "Allows a user to restore a backup for the server. Danger: this allows the user to delete all of the server files in the process.",
+		},
+	},
+	"allocation": {
+		Description: "Permissions that control a user's ability to modify the port allocations for this server.",
+		Keys: map[string]string{
+			"read":   "Allows a user to view all allocations currently assigned to this server. Users with any level of access to this server can always view the primary allocation.",
+			"create": "Allows a user to assign additional allocations to the server.",
+			"update": "Allows a user to change the primary server allocation and attach notes to each allocation.",
+			"delete": "Allows a user to delete an allocation from the server.",
+		},
+	},
+	"startup": {
+		Description: "Permissions that control a user's ability to view this server's startup parameters.",
+		Keys: map[string]string{
+			"read":         "Allows a user to view the startup variables for a server.",
+			"update":       "Allows a user to modify the startup variables for the server.",
+			"docker-image": "Allows a user to modify the Docker image used when running the server.",
+		},
+	},
+	"database": {
+		Description: "Permissions that control a user's access to the database management for this server.",
+		Keys: map[string]string{
+			"create":        "Allows a user to create a new database for this server.",
+			"read":          "Allows a user to view the database associated with this server.",
+			"update":        "Allows a user to rotate the password on a database instance. If the user does not have the view_password permission they will not see the updated password.",
+			"delete":        "Allows a user to remove a database instance from this server.",
+			"view_password": "Allows a user to view the password associated with a database instance for this server.",
+		},
+	},
+	"schedule": {
+		Description: "Permissions that control a user's access to the schedule management for this server.",
+		Keys: map[string]string{
+			"create": "Allows a user to create new schedules for this server.",
+			"read":   "Allows a user to view schedules and the tasks associated with them for this server.",
+			"update": "Allows a user to update schedules and schedule tasks for this server.",
+			"delete": "Allows a user to delete schedules for this server.",
+		},
+	},
+	"settings": {
+		Description: "Permissions that control a user's access to the settings for this server.",
+		Keys: map[string]string{
+			"rename":    "Allows a user to rename this server and change the description of it.",
+			"reinstall": "Allows a user to trigger a reinstall of this server.",
+		},
+	},
+	"activity": {
+		Description: "Permissions that control a user's access to the server activity logs.",
+		Keys: map[string]string{
+			"read": "Allows a user to view the activity logs for the server.",
+		},
+	},
+}
+
+func (p *Panel) getPermissions(w http.ResponseWriter, r *http.Request, u *alligator.User) {
+	writeJSON(w, http.StatusOK, item("system_permissions", document{"permissions": permissionGroups}))
+}
+
+func (p *Panel) subuserDoc(su *subuser) document {
+	u := p.users[su.user]
+	if u == nil {
+		u = &alligator.User{}
+	}
+	return item("server_subuser", alligator.Subuser{
+		UUID:             u.UUID,
+		Username:         u.Username,
+		Email:            u.Email,
+		Image:            fmt.Sprintf("https://gravatar.com/avatar/%x", md5.Sum([]byte(strings.ToLower(u.Email)))),
+		TwoFactorEnabled: u.TwoFactor,
+		CreatedAt:        su.createdAt,
+		Permissions:      su.permissions,
+	})
+}
+
+func (p *Panel) clientSubuser(w http.ResponseWriter, r *http.Request, s *server) (*subuser, bool) {
+	for _, su := range s.subusers {
+		if u := p.users[su.user]; u != nil && u.UUID == r.PathValue("user") {
+			return su, true
+		}
+	}
+	notFound(w)
+	return nil, false
+}
+
+// subuserPermissions drops unknown permissions and the ones a requesting subuser doesn't have,
+// websocket.connect is always granted.
+func (p *Panel) subuserPermissions(r *http.Request, s *server, permissions []alligator.Permission) []alligator.Permission {
+	var held map[alligator.Permission]bool
+	if u := p.requester(r); u != nil && s.UserID != u.ID && !u.RootAdmin {
+		held = make(map[alligator.Permission]bool)
+		if su := s.subuser(u.ID); su != nil {
+			for _, perm := range su.permissions {
+				held[perm] = true
+			}
+		}
+	}
+
+	out := []alligator.Permission{}
+	for _, perm := range append(permissions, alligator.PermissionWebsocketConnect) {
+		if perm.Valid() && (held == nil || held[perm]) && !slices.Contains(out, perm) {
+			out = append(out, perm)
+		}
+	}
+	return out
+}
+
+func (p *Panel) listSubusers(w http.ResponseWriter, r *http.Request, s *server) {
+	docs := make([]document, 0, len(s.subusers))
+	for _, su := range s.subusers {
+		docs = append(docs, p.subuserDoc(su))
+	}
+	writeJSON(w, http.StatusOK, list(docs))
+}
+
+func (p *Panel) createSubuser(w http.ResponseWriter, r *http.Request, s *server) {
+	var body struct {
+		Email       string                 `json:"email"`
+		Permissions []alligator.Permission `json:"permissions"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var errs []fieldError
+	errs = required(errs, "email", body.Email != "")
+	errs = required(errs, "permissions", body.Permissions != nil)
+	if body.Email != "" && !strings.Contains(body.Email, "@") {
+		errs = append(errs, fieldError{"email", "email", "The email must be a valid email address."})
+	}
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	var u *alligator.User
+	for _, id := range sortedKeys(p.users) {
+		if strings.EqualFold(p.users[id].Email, body.Email) {
+			u = p.users[id]
+		}
+	}
+	switch {
+	case u != nil && u.ID == s.UserID:
+		writeError(w, http.StatusBadRequest, "Cannot add the server owner as a subuser of this server.")
+		return
+	case u != nil && s.subuser(u.ID) != nil:
+		writeError(w, http.StatusBadRequest, "A user with that email address is already assigned as a subuser for this server.")
+		return
+	case u == nil:
+		// The panel creates an account and emails the user a link to set its password
+		name, _, _ := strings.Cut(body.Email, "@")
+		u = &alligator.User{
+			ID:        p.next("user"),
+			UUID:      newUUID(),
+			Username:  name + "_" + randomString(4),
+			Email:     body.Email,
+			Language:  "en",
+			CreatedAt: now(),
+		}
+		u.UpdatedAt = u.CreatedAt
+		p.users[u.ID] = u
+	}
+
+	su := &subuser{user: u.ID, permissions: p.subuserPermissions(r, s, body.Permissions), createdAt: now()}
+	s.subusers = append(s.subusers, su)
+
+	writeJSON(w, http.StatusOK, p.subuserDoc(su))
+}
+
+func (p *Panel) getSubuser(w http.ResponseWriter, r *http.Request, s *server) {
+	if su, ok := p.clientSubuser(w, r, s); ok {
+		writeJSON(w, http.StatusOK, p.subuserDoc(su))
+	}
+}
+
+func (p *Panel) updateSubuser(w http.ResponseWriter, r *http.Request, s *server) {
+	su, ok := p.clientSubuser(w, r, s)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Permissions []alligator.Permission `json:"permissions"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body.Permissions == nil {
+		writeValidation(w, required(nil, "permissions", false))
+		return
+	}
+	if u := p.requester(r); u != nil && u.ID == su.user {
+		writeError(w, http.StatusForbidden, "You are not permitted to modify your own permissions.")
+		return
+	}
+
+	su.permissions = p.subuserPermissions(r, s, body.Permissions)
+	writeJSON(w, http.StatusOK, p.subuserDoc(su))
+}
+
+func (p *Panel) deleteSubuser(w http.ResponseWriter, r *http.Request, s *server) {
+	if su, ok := p.clientSubuser(w, r, s); ok {
+		s.subusers = slices.DeleteFunc(s.subusers, func(other *subuser) bool { return other == su })
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Network
+
+func clientAllocationDoc(s *server, a *allocation) document {
+	return item("allocation", alligator.ClientAllocation{
+		ID:        a.ID,
+		IP:        a.IP,
+		Alias:     a.Alias,
+		Port:      a.Port,
+		Notes:     a.Notes,
+		IsDefault: a.ID == s.Allocation,
+	})
+}
+
+// serverAllocations returns the allocations assigned to a server, by id.
+func (p *Panel) serverAllocations(s *server) []*allocation {
+	var out []*allocation
+	for _, id := range sortedKeys(p.allocations) {
+		if a := p.allocations[id]; a.server == s.ID {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func (p *Panel) clientAllocation(w http.ResponseWriter, r *http.Request, s *server) (*allocation, bool) {
+	id, _ := strconv.Atoi(r.PathValue("allocation"))
+	if a, ok := p.allocations[id]; ok && a.server == s.ID {
+		return a, true
+	}
+	notFound(w)
+	return nil, false
+}
+
+func (p *Panel) listClientAllocations(w http.ResponseWriter, r *http.Request, s *server) {
+	var docs []document
+	for _, a := range p.serverAllocations(s) {
+		docs = append(docs, clientAllocationDoc(s, a))
+	}
+	writeJSON(w, http.StatusOK, list(docs))
+}
+
+// assignAllocation picks the first free allocation on the node and IP of the primary allocation.
+func (p *Panel) assignAllocation(w http.ResponseWriter, r *http.Request, s *server) {
+	if len(p.serverAllocations(s)) >= s.FeatureLimits.Allocations {
+		writeError(w, http.StatusBadRequest, "Cannot assign additional allocations to this server: limit has been reached.")
+		return
+	}
+
+	var ip string
+	if primary, ok := p.allocations[s.Allocation]; ok {
+		ip = primary.IP
+	}
+	for _, id := range sortedKeys(p.allocations) {
+		if a := p.allocations[id]; a.server == 0 && a.node == s.NodeID && (ip == "" || a.IP == ip) {
+			a.server, a.Assigned = s.ID, true
+			writeJSON(w, http.StatusOK, clientAllocationDoc(s, a))
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, "Cannot assign additional allocation: no more space available on node.")
+}
+
+func (p *Panel) updateAllocationNotes(w http.ResponseWriter, r *http.Request, s *server) {
+	a, ok := p.clientAllocation(w, r, s)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Notes *string `json:"notes"`
+	}
+	if err := decode(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var notes string
+	if body.Notes != nil {
+		notes = *body.Notes
+	}
+	if len(notes) > 255 {
+		writeValidation(w, []fieldError{{"notes", "max", "The notes may not be greater than 255 characters."}})
+		return
+	}
+
+	a.Notes = notes
+	writeJSON(w, http.StatusOK, clientAllocationDoc(s, a))
+}
+
+func (p *Panel) setPrimaryAllocation(w http.ResponseWriter, r *http.Request, s *server) {
+	if a, ok := p.clientAllocation(w, r, s); ok {
+		s.Allocation = a.ID
+		writeJSON(w, http.StatusOK, clientAllocationDoc(s, a))
+	}
+}
+
+func (p *Panel) deleteClientAllocation(w http.ResponseWriter, r *http.Request, s *server) {
+	a, ok := p.clientAllocation(w, r, s)
+	if !ok {
+		return
+	}
+	if s.FeatureLimits.Allocations == 0 {
+		writeError(w, http.StatusBadRequest, "You cannot delete allocations for this server: no allocation limit is set.")
+		return
+	}
+	if a.ID == s.Allocation {
+		writeError(w, http.StatusBadRequest, "You cannot delete the primary allocation for this server.")
+		return
+	}
+
+	a.server, a.Assigned, a.Notes = 0, false, ""
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Transfers
+
+func (p *Panel) transferRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /_transfer/download", p.transfer(false, func(w http.ResponseWriter, r *http.Request, s *server, name string) {
+		f, ok := s.files[name]
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(name)))
+		w.Write(f.data)
+	}))
+
+	mux.HandleFunc("GET /_transfer/backup", p.transfer(false, func(w http.ResponseWriter, r *http.Request, s *server, uuid string) {
+		for _, b := range s.backups {
+			if b.UUID == uuid {
+				w.Header().Set("Content-Type", "application/gzip")
+				w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", uuid+".tar.gz"))
+				w.Write(b.data)
+				return
+			}
+		}
+		notFound(w)
+	}))
+
+	mux.HandleFunc("POST /_transfer/upload", p.transfer(true, func(w http.ResponseWriter, r *http.Request, s *server, dir string) {
+		if d := r.URL.Query().Get("directory"); d != "" {
+			dir = cleanPath(d)
+		}
+
+		form, err := r.MultipartReader()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for {
+			part, err := form.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if part.FormName() != "files" {
+				continue
+			}
+
+			data, err := io.ReadAll(part)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			s.writeFile(path.Join(dir, part.FileName()), data)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// transfer resolves the signed token of a download or upload url. Tokens can be used once.
+func (p *Panel) transfer(upload bool, h func(http.ResponseWriter, *http.Request, *server, string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		token := r.URL.Query().Get("token")
+		t, ok := p.transfers[token]
+		s := p.servers[t.server]
+		if !ok || t.upload != upload || s == nil {
+			writeError(w, http.StatusForbidden, "The signed url is invalid or has expired.")
+			return
+		}
+		delete(p.transfers, token)
+
+		h(w, r, s, t.path)
+	}
+}
@@ -0,0 +1,415 @@
+package alligatortest
+
+import (
+	"fmt"
+	"github.com/m41denx/alligator"
+	"path"
+	"time"
+)
+
+// Fixtures seeds the panel in dependency order. Objects with an ID keep it so fixtures
+// can reference each other, the others get the next free ID.
+type Fixtures struct {
+	Users       []*alligator.User
+	Locations   []*alligator.Location
+	Nodes       []*alligator.Node
+	Allocations []*alligator.Allocation // Node must be set to the owning node
+	Nests       []*alligator.Nest
+	Eggs        []*alligator.Egg
+	Servers     []*alligator.AppServer
+	Databases   []*alligator.AppDatabase // ServerID must be set to the owning server
+	Mounts      []*alligator.Mount       // Eggs, Nodes and Servers only need their ID set
+}
+
+func (p *Panel) Seed(f Fixtures) {
+	for _, u := range f.Users {
+		p.AddUser(u)
+	}
+	for _, l := range f.Locations {
+		p.AddLocation(l)
+	}
+	for _, n := range f.Nodes {
+		p.AddNode(n)
+	}
+	for _, a := range f.Allocations {
+		node := 0
+		if a.Node != nil {
+			node = a.Node.ID
+		}
+		p.AddAllocation(node, a)
+	}
+	for _, n := range f.Nests {
+		p.AddNest(n)
+	}
+	for _, e := range f.Eggs {
+		p.AddEgg(e)
+	}
+	for _, s := range f.Servers {
+		p.AddServer(s)
+	}
+	for _, d := range f.Databases {
+		p.AddDatabase(d)
+	}
+	for _, m := range f.Mounts {
+		p.AddMount(m)
+	}
+}
+
+// DefaultServer is the identifier of the server in DefaultFixtures.
+const DefaultServer = "5a1b9e07"
+
+// DefaultFixtures is a small panel to test against: user 1 "owner" and root admin 2 "admin", location 1 "eu",
+// node 1 "node-1" with allocations 1 (10.0.0.1:25565) and 2 (10.0.0.1:25566), the Paper egg 1 in nest 1
+// and server 1 "survival" owned by user 1 on allocation 1, allowed one database.
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		Users: []*alligator.User{
+			{ID: 1, Username: "owner", Email: "owner@example.com", FirstName: "Server", LastName: "Owner"},
+			{ID: 2, Username: "admin", Email: "admin@example.com", FirstName: "Root", LastName: "Admin", RootAdmin: true},
+		},
+		Locations: []*alligator.Location{{ID: 1, Short: "eu", Long: "Europe"}},
+		Nodes:     []*alligator.Node{{ID: 1, Name: "node-1", LocationID: 1, FQDN: "node1.example.com"}},
+		Allocations: []*alligator.Allocation{
+			{ID: 1, IP: "10.0.0.1", Port: 25565, Node: &alligator.Node{ID: 1}},
+			{ID: 2, IP: "10.0.0.1", Port: 25566, Node: &alligator.Node{ID: 1}},
+		},
+		Nests: []*alligator.Nest{{ID: 1, Name: "Minecraft"}},
+		Eggs: []*alligator.Egg{{
+			ID: 1, NestID: 1, Name: "Paper", DockerImage: "ghcr.io/pterodactyl/yolks:java_17", Startup: "java -jar server.jar",
+			Variables: []*alligator.EggVariable{{EnvVariable: "SERVER_JARFILE", DefaultValue: "server.jar"}},
+		}},
+		Servers: []*alligator.AppServer{{
+			ID: 1, Identifier: DefaultServer, Name: "survival", UserID: 1, Allocation: 1, NestID: 1, EggID: 1,
+			FeatureLimits: alligator.FeatureLimits{Databases: 1},
+		}},
+	}
+}
+
+func (p *Panel) id(kind string, id int) int {
+	if id == 0 {
+		return p.next(kind)
+	}
+	if id > p.seq[kind] {
+		p.seq[kind] = id
+	}
+	return id
+}
+
+func (p *Panel) AddUser(u *alligator.User) *alligator.User {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user := *u
+	user.ID = p.id("user", user.ID)
+	if user.UUID == "" {
+		user.UUID = newUUID()
+	}
+	if user.Language == "" {
+		user.Language = "en"
+	}
+	if user.CreatedAt == nil {
+		user.CreatedAt = now()
+	}
+	user.Servers = nil
+	p.users[user.ID] = &user
+
+	out := user
+	return &out
+}
+
+func (p *Panel) AddLocation(l *alligator.Location) *alligator.Location {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	loc := *l
+	loc.ID = p.id("location", loc.ID)
+	if loc.CreatedAt == nil {
+		loc.CreatedAt = now()
+	}
+	loc.Nodes, loc.Servers = nil, nil
+	p.locations[loc.ID] = &loc
+
+	out := loc
+	return &out
+}
+
+func (p *Panel) AddNode(n *alligator.Node) *alligator.Node {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	node := *n
+	node.ID = p.id("node", node.ID)
+	if node.Scheme == "" {
+		node.Scheme = "https"
+	}
+	if node.DaemonListen == 0 {
+		node.DaemonListen = 8080
+	}
+	if node.DaemonSftp == 0 {
+		node.DaemonSftp = 2022
+	}
+	if node.DaemonBase == "" {
+		node.DaemonBase = "/var/lib/pterodactyl/volumes"
+	}
+	if node.CreatedAt == nil {
+		node.CreatedAt = now()
+	}
+	node.Location, node.Allocations, node.Servers = nil, nil, nil
+	p.nodes[node.ID] = &node
+
+	out := node
+	return &out
+}
+
+func (p *Panel) AddAllocation(node int, a *alligator.Allocation) *alligator.Allocation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	alloc := *a
+	alloc.ID = p.id("allocation", alloc.ID)
+	alloc.Node, alloc.Server = nil, nil
+	p.allocations[alloc.ID] = &allocation{Allocation: &alloc, node: node}
+
+	out := alloc
+	return &out
+}
+
+func (p *Panel) AddNest(n *alligator.Nest) *alligator.Nest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nest := *n
+	nest.ID = p.id("nest", nest.ID)
+	if nest.UUID == "" {
+		nest.UUID = newUUID()
+	}
+	if nest.CreatedAt.IsZero() {
+		nest.CreatedAt = *now()
+	}
+	nest.Eggs, nest.Servers = nil, nil
+	p.nests[nest.ID] = &nest
+
+	out := nest
+	return &out
+}
+
+// AddEgg adds an egg to the nest set in NestID, along with its Variables.
+func (p *Panel) AddEgg(e *alligator.Egg) *alligator.Egg {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	egg := *e
+	egg.ID = p.id("egg", egg.ID)
+	if egg.UUID == "" {
+		egg.UUID = newUUID()
+	}
+	if egg.CreatedAt.IsZero() {
+		egg.CreatedAt = *now()
+	}
+	egg.Variables = make([]*alligator.EggVariable, 0, len(e.Variables))
+	for _, v := range e.Variables {
+		variable := *v
+		variable.ID = p.id("variable", variable.ID)
+		variable.EggID = egg.ID
+		egg.Variables = append(egg.Variables, &variable)
+	}
+	egg.NestObject, egg.Servers = nil, nil
+	p.eggs[egg.ID] = &egg
+
+	out := egg
+	return &out
+}
+
+// AddServer adds a server and assigns its default allocation to it.
+func (p *Panel) AddServer(s *alligator.AppServer) *alligator.AppServer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	srv := *s
+	srv.ID = p.id("server", srv.ID)
+	if srv.UUID == "" {
+		srv.UUID = newUUID()
+	}
+	if srv.Identifier == "" {
+		srv.Identifier = srv.UUID[:8]
+	}
+	if srv.CreatedAt == nil {
+		srv.CreatedAt = now()
+	}
+	if srv.Container.Environment == nil {
+		srv.Container.Environment = make(map[string]interface{})
+	}
+	srv.Allocations, srv.UserObject, srv.Subusers, srv.Location = nil, nil, nil, nil
+	srv.NodeObject, srv.NestObject, srv.EggObject, srv.Variables = nil, nil, nil, nil
+
+	if alloc, ok := p.allocations[srv.Allocation]; ok {
+		alloc.Assigned = true
+		alloc.server = srv.ID
+		if srv.NodeID == 0 {
+			srv.NodeID = alloc.node
+		}
+	}
+
+	p.servers[srv.ID] = &server{
+		AppServer: &srv,
+		state:     "offline",
+		files:     map[string]*file{"/": {dir: true, mode: 0o755, modified: time.Now()}},
+	}
+
+	out := srv
+	return &out
+}
+
+// AddFile writes a file to a server, creating its parent directories.
+func (p *Panel) AddFile(identifier, name string, data []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.serverByIdentifier(identifier); s != nil {
+		s.writeFile(name, data)
+	}
+}
+
+// AddDatabase adds a database to the server set in ServerID. Database gets the
+// s<server>_ prefix the panel adds, and an empty Password is generated.
+func (p *Panel) AddDatabase(db *alligator.AppDatabase) *alligator.AppDatabase {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, ok := p.servers[db.ServerID]
+	if !ok {
+		return nil
+	}
+
+	out := *p.newDatabase(s, db).AppDatabase
+	return &out
+}
+
+func (p *Panel) newDatabase(s *server, db *alligator.AppDatabase) *database {
+	d := *db
+	d.ID = p.id("database", d.ID)
+	d.ServerID = s.ID
+	d.HostID = p.databaseHost.ID
+	d.Database = fmt.Sprintf("s%d_%s", s.ID, d.Database)
+	if d.Username == "" {
+		d.Username = fmt.Sprintf("u%d_%s", s.ID, randomString(10))
+	}
+	if d.Password == "" {
+		d.Password = randomString(24)
+	}
+	if d.CreatedAt == nil {
+		d.CreatedAt = now()
+	}
+	d.HostObject = nil
+
+	created := &database{AppDatabase: &d, hashid: randomString(8)}
+	s.databases = append(s.databases, created)
+	return created
+}
+
+// AddMount adds a mount attached to the eggs, nodes and servers listed in it. Servers can
+// only be attached this way, as the application API has no endpoint for it.
+func (p *Panel) AddMount(m *alligator.Mount) *alligator.Mount {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	mnt := *m
+	mnt.ID = p.id("mount", mnt.ID)
+	if mnt.UUID == "" {
+		mnt.UUID = newUUID()
+	}
+	created := &mount{Mount: &mnt, eggs: make(map[int]bool), nodes: make(map[int]bool), servers: make(map[int]bool)}
+	for _, e := range m.Eggs {
+		created.eggs[e.ID] = true
+	}
+	for _, n := range m.Nodes {
+		created.nodes[n.ID] = true
+	}
+	for _, s := range m.Servers {
+		created.servers[s.ID] = true
+	}
+	mnt.Eggs, mnt.Nodes, mnt.Servers = nil, nil, nil
+	p.mounts[mnt.ID] = created
+
+	out := mnt
+	return &out
+}
+
+// PowerState returns the state of a server: offline, running or stopping.
+func (p *Panel) PowerState(identifier string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.serverByIdentifier(identifier); s != nil {
+		return s.state
+	}
+	return ""
+}
+
+// SetPowerState changes the state of a server without going through the API.
+func (p *Panel) SetPowerState(identifier, state string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.serverByIdentifier(identifier); s != nil {
+		s.state, s.started = state, time.Now()
+		p.broadcast(s, "status", state)
+	}
+}
+
+// Commands returns the console commands sent to a server.
+func (p *Panel) Commands(identifier string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.serverByIdentifier(identifier); s != nil {
+		return append([]string(nil), s.commands...)
+	}
+	return nil
+}
+
+// File returns the contents of a server file.
+func (p *Panel) File(identifier, name string) ([]byte, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := p.serverByIdentifier(identifier)
+	if s == nil {
+		return nil, false
+	}
+	f, ok := s.files[cleanPath(name)]
+	if !ok || f.dir {
+		return nil, false
+	}
+	return append([]byte(nil), f.data...), true
+}
+
+func (p *Panel) serverByIdentifier(identifier string) *server {
+	for _, s := range p.servers {
+		if s.Identifier == identifier || s.UUID == identifier {
+			return s
+		}
+	}
+	return nil
+}
+
+func cleanPath(name string) string {
+	return path.Clean("/" + name)
+}
+
+func (s *server) writeFile(name string, data []byte) {
+	name = cleanPath(name)
+	s.mkdirAll(path.Dir(name))
+	s.files[name] = &file{data: data, mode: 0o644, modified: time.Now()}
+}
+
+func (s *server) mkdirAll(dir string) {
+	for dir = cleanPath(dir); ; dir = path.Dir(dir) {
+		if _, ok := s.files[dir]; !ok {
+			s.files[dir] = &file{dir: true, mode: 0o755, modified: time.Now()}
+		}
+		if dir == "/" {
+			break
+		}
+	}
+}
@@ -0,0 +1,516 @@
+// Package alligatortest runs an in-memory fake Pterodactyl panel for testing code built on alligator.
+//
+//	panel := alligatortest.NewPanel()
+//	defer panel.Close()
+//
+//	user := panel.AddUser(&alligator.User{Username: "example", Email: "example@example.com"})
+//	app, _ := alligator.NewApp(panel.URL, panel.AppKey)
+//	client, _ := alligator.NewClient(panel.URL, panel.ClientKey(user.ID))
+package alligatortest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/m41denx/alligator"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Panel struct {
+	URL    string
+	AppKey string
+
+	// RateLimit is the number of requests per minute advertised through the
+	// X-RateLimit-* headers. It is only advertised, never enforced. 0 disables the headers.
+	RateLimit int
+
+	srv    *httptest.Server
+	mu     sync.Mutex
+	faults []*Fault
+	window time.Time
+	count  int
+
+	clientKeys   map[string]int
+	databaseHost *alligator.DatabaseHost
+	users        map[int]*alligator.User
+	locations    map[int]*alligator.Location
+	nodes        map[int]*alligator.Node
+	allocations  map[int]*allocation
+	nests        map[int]*alligator.Nest
+	eggs         map[int]*alligator.Egg
+	servers      map[int]*server
+	mounts       map[int]*mount
+	transfers    map[string]transfer
+	socketTokens map[string]int // Websocket token => server id
+	seq          map[string]int
+}
+
+type allocation struct {
+	*alligator.Allocation
+	node   int
+	server int
+}
+
+type server struct {
+	*alligator.AppServer
+	state     string
+	started   time.Time
+	commands  []string
+	output    []string // Console output replayed by "send logs"
+	consoles  []*console
+	files     map[string]*file
+	databases []*database
+	backups   []*backup
+	schedules []*alligator.Schedule // Tasks are kept in sequence order
+	subusers  []*subuser
+}
+
+// subuser gives a user access to a server, it refers to the user by id since updates replace it.
+type subuser struct {
+	user        int
+	permissions []alligator.Permission
+	createdAt   *time.Time
+}
+
+func (s *server) subuser(user int) *subuser {
+	for _, su := range s.subusers {
+		if su.user == user {
+			return su
+		}
+	}
+	return nil
+}
+
+// database is shared by both APIs, the client API refers to it by its hashid.
+type database struct {
+	*alligator.AppDatabase
+	hashid string
+}
+
+// mount keeps the ids of the eggs, nodes and servers attached to it.
+type mount struct {
+	*alligator.Mount
+	eggs    map[int]bool
+	nodes   map[int]bool
+	servers map[int]bool
+}
+
+// backup keeps a copy of the server files taken when it was created, and their archive.
+type backup struct {
+	*alligator.Backup
+	files map[string]*file
+	data  []byte
+}
+
+// transfer is a signed download or upload url handed out by the client API.
+type transfer struct {
+	server int
+	path   string
+	upload bool
+}
+
+type file struct {
+	data     []byte
+	dir      bool
+	mode     uint32
+	modified time.Time
+}
+
+// Fault makes the panel misbehave for matching requests.
+type Fault struct {
+	Method     string        // Only affect requests with this method, empty for any
+	Path       string        // Only affect requests whose path starts with Path, empty for any
+	Latency    time.Duration // Delay before handling the request
+	Status     int           // Respond with this status instead of handling the request, 0 to only add latency
+	RetryAfter int           // Seconds sent in the Retry-After header along with Status
+	Times      int           // Number of requests affected, 0 until ClearFaults is called
+}
+
+func NewPanel() *Panel {
+	p := &Panel{
+		AppKey:     "ptla_" + randomString(16),
+		clientKeys: make(map[string]int),
+		databaseHost: &alligator.DatabaseHost{
+			ID:        1,
+			Name:      "localhost",
+			Host:      "127.0.0.1",
+			Port:      3306,
+			Username:  "pterodactyl",
+			CreatedAt: now(),
+		},
+		users:        make(map[int]*alligator.User),
+		locations:    make(map[int]*alligator.Location),
+		nodes:        make(map[int]*alligator.Node),
+		allocations:  make(map[int]*allocation),
+		nests:        make(map[int]*alligator.Nest),
+		eggs:         make(map[int]*alligator.Egg),
+		servers:      make(map[int]*server),
+		mounts:       make(map[int]*mount),
+		transfers:    make(map[string]transfer),
+		socketTokens: make(map[string]int),
+		seq:          make(map[string]int),
+	}
+
+	p.srv = httptest.NewServer(p.routes())
+	p.URL = p.srv.URL
+
+	return p
+}
+
+func (p *Panel) Close() {
+	p.srv.Close()
+}
+
+// ClientKey returns a client API key acting as the given user.
+func (p *Panel) ClientKey(user int) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, id := range p.clientKeys {
+		if id == user {
+			return key
+		}
+	}
+
+	key := "ptlc_" + randomString(16)
+	p.clientKeys[key] = user
+	return key
+}
+
+func (p *Panel) Inject(f Fault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.faults = append(p.faults, &f)
+}
+
+func (p *Panel) ClearFaults() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.faults = nil
+}
+
+func (p *Panel) routes() http.Handler {
+	mux := http.NewServeMux()
+	p.applicationRoutes(mux)
+	p.clientRoutes(mux)
+	p.transferRoutes(mux)
+	p.wingsRoutes(mux)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f := p.fault(r)
+		if f != nil && f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		p.rateLimitHeaders(w)
+
+		if f != nil && f.Status != 0 {
+			if f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+			}
+			writeError(w, f.Status, http.StatusText(f.Status))
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (p *Panel) fault(r *http.Request) *Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, f := range p.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				p.faults = append(p.faults[:i:i], p.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+
+	return nil
+}
+
+func (p *Panel) rateLimitHeaders(w http.ResponseWriter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.RateLimit <= 0 {
+		return
+	}
+
+	if now := time.Now(); now.Sub(p.window) > time.Minute {
+		p.window = now
+		p.count = 0
+	}
+	p.count++
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(p.RateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(p.RateLimit-p.count, 0)))
+}
+
+func (p *Panel) next(kind string) int {
+	p.seq[kind]++
+	return p.seq[kind]
+}
+
+func randomString(n int) string {
+	b := make([]byte, n/2)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func now() *time.Time {
+	t := time.Now().UTC().Truncate(time.Second)
+	return &t
+}
+
+// Responses
+
+type document map[string]interface{}
+
+func item(object string, attributes interface{}) document {
+	return document{"object": object, "attributes": attributes}
+}
+
+func list(items []document) document {
+	if items == nil {
+		items = []document{}
+	}
+	return document{"object": "list", "data": items}
+}
+
+// attributes converts a model to its JSON attributes so relationships can be added.
+func attributes(v interface{}) map[string]interface{} {
+	buf, _ := json.Marshal(v)
+	var m map[string]interface{}
+	json.Unmarshal(buf, &m)
+	return m
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	codes := map[int]string{
+		http.StatusBadRequest:          "BadRequestHttpException",
+		http.StatusUnauthorized:        "AuthenticationException",
+		http.StatusForbidden:           "AccessDeniedHttpException",
+		http.StatusNotFound:            "NotFoundHttpException",
+		http.StatusConflict:            "ConflictHttpException",
+		http.StatusTooManyRequests:     "TooManyRequestsHttpException",
+		http.StatusUnprocessableEntity: "ValidationException",
+	}
+	code, ok := codes[status]
+	if !ok {
+		code = "HttpException"
+	}
+
+	writeJSON(w, status, document{"errors": []document{{
+		"code":   code,
+		"status": strconv.Itoa(status),
+		"detail": detail,
+	}}})
+}
+
+type fieldError struct {
+	field, rule, detail string
+}
+
+func writeValidation(w http.ResponseWriter, errs []fieldError) {
+	out := make([]document, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, document{
+			"code":   "ValidationException",
+			"status": "422",
+			"detail": e.detail,
+			"meta":   document{"source_field": e.field, "rule": e.rule},
+		})
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, document{"errors": out})
+}
+
+// environmentErrors checks env against the egg variable rules, like the panel does for "environment.*".
+func environmentErrors(errs []fieldError, egg *alligator.Egg, env map[string]interface{}) []fieldError {
+	for _, v := range egg.Variables {
+		field := "environment." + v.EnvVariable
+		fes, err := v.Validate(env[v.EnvVariable])
+		if err != nil {
+			errs = append(errs, fieldError{field, "rules", err.Error()})
+			continue
+		}
+		for _, fe := range fes {
+			errs = append(errs, fieldError{field, fe.Rule, fe.Detail})
+		}
+	}
+	return errs
+}
+
+func required(errs []fieldError, field string, present bool) []fieldError {
+	if present {
+		return errs
+	}
+	detail := fmt.Sprintf("The %s field is required.", strings.ReplaceAll(field, "_", " "))
+	return append(errs, fieldError{field, "required", detail})
+}
+
+// writeList applies the filter[*], sort, page and per_page query parameters to items.
+// Filters in handled were already applied by the caller.
+func writeList(w http.ResponseWriter, r *http.Request, items []document, handled ...string) {
+	q := r.URL.Query()
+
+	filtered := make([]document, 0, len(items))
+	for _, it := range items {
+		if matches(it["attributes"].(map[string]interface{}), q, handled) {
+			filtered = append(filtered, it)
+		}
+	}
+
+	if key := q.Get("sort"); key != "" {
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a := filtered[i]["attributes"].(map[string]interface{})[key]
+			b := filtered[j]["attributes"].(map[string]interface{})[key]
+			if desc {
+				return less(b, a)
+			}
+			return less(a, b)
+		})
+	}
+
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage <= 0 {
+		perPage = 50
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pages := max((len(filtered)+perPage-1)/perPage, 1)
+
+	start := min((page-1)*perPage, len(filtered))
+	end := min(start+perPage, len(filtered))
+	data := filtered[start:end]
+
+	links := document{}
+	if page < pages {
+		u := *r.URL
+		q.Set("page", strconv.Itoa(page+1))
+		u.RawQuery = q.Encode()
+		links["next"] = "http://" + r.Host + u.String()
+	}
+
+	doc := list(data)
+	doc["meta"] = document{"pagination": document{
+		"total":        len(filtered),
+		"count":        len(data),
+		"per_page":     perPage,
+		"current_page": page,
+		"total_pages":  pages,
+		"links":        links,
+	}}
+	writeJSON(w, http.StatusOK, doc)
+}
+
+func matches(attrs map[string]interface{}, q map[string][]string, handled []string) bool {
+	for key, values := range q {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+		field := key[len("filter[") : len(key)-1]
+		want := strings.ToLower(values[0])
+
+		if slices.Contains(handled, field) {
+			continue
+		}
+
+		switch v := attrs[field].(type) {
+		case string:
+			if !strings.Contains(strings.ToLower(v), want) {
+				return false
+			}
+		case nil:
+			if want != "" {
+				return false
+			}
+		default:
+			if strings.ToLower(fmt.Sprint(v)) != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func less(a, b interface{}) bool {
+	fa, okA := a.(float64)
+	fb, okB := b.(float64)
+	if okA && okB {
+		return fa < fb
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func includes(r *http.Request) map[string]bool {
+	inc := make(map[string]bool)
+	for _, v := range strings.Split(r.URL.Query().Get("include"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			inc[v] = true
+		}
+	}
+	return inc
+}
+
+func decode(r *http.Request, v interface{}) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func pathID(r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	return id, err == nil
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
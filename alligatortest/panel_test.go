@@ -0,0 +1,205 @@
+package alligatortest
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApplication(t *testing.T) {
+	p := NewPanel()
+	defer p.Close()
+	p.Seed(DefaultFixtures())
+
+	app, err := alligator.NewApp(p.URL, p.AppKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		p.AddUser(&alligator.User{Username: "user" + string(rune('a'+i)), Email: string(rune('a'+i)) + "@example.com"})
+	}
+	users, err := app.ListAllUsers(options.ListUsersOptions{PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 7 {
+		t.Fatalf("expected 7 users across pages, got %d", len(users))
+	}
+
+	egg, err := app.GetEgg(1, 1, options.GetEggOptions{Include: options.IncludeEggs{Nest: true, Variables: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if egg.NestObject == nil || egg.NestObject.Name != "Minecraft" || len(egg.Variables) != 1 {
+		t.Errorf("egg relationships were not included: %+v %+v", egg.NestObject, egg.Variables)
+	}
+
+	_, err = app.CreateUser(alligator.CreateUserDescriptor{Username: "owner", Email: "new@example.com"})
+	var verr *alligator.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if verr.Fields["username"][0].Rule != "unique" || verr.Fields["first_name"][0].Rule != "required" {
+		t.Errorf("unexpected validation fields: %+v", verr.Fields)
+	}
+
+	srv, err := app.CreateServer(alligator.CreateServerDescriptor{
+		Name:       "creative",
+		ExternalID: "creative-1",
+		User:       1,
+		Egg:        1,
+		Limits:     &alligator.Limits{Memory: 1024},
+		Allocation: &alligator.AllocationDescriptor{Default: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.NodeID != 1 || srv.Container.Image != "ghcr.io/pterodactyl/yolks:java_17" || srv.Container.Environment["SERVER_JARFILE"] != "server.jar" {
+		t.Errorf("server was not created from its egg and allocation: %+v", srv)
+	}
+
+	if ext, err := app.GetServerExternal("creative-1"); err != nil || ext.ID != srv.ID {
+		t.Errorf("expected to find the server by its external id, got %v", err)
+	}
+
+	srv, err = app.GetServer(srv.ID, options.GetServerOptions{Include: options.IncludeServers{Allocations: true, User: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Allocations) != 1 || srv.Allocations[0].Port != 25566 || srv.UserObject == nil || srv.UserObject.Username != "owner" {
+		t.Errorf("relationships were not included: %+v %+v", srv.Allocations, srv.UserObject)
+	}
+
+	if err = app.DeleteNodeAllocation(1, 2); err == nil {
+		t.Error("expected deleting an assigned allocation to fail")
+	}
+	if err = app.DeleteServer(srv.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.GetServer(srv.ID); !errors.Is(err, alligator.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient(t *testing.T) {
+	p := NewPanel()
+	defer p.Close()
+	p.Seed(DefaultFixtures())
+
+	client, err := alligator.NewClient(p.URL, p.ClientKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servers, err := client.GetServers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 1 || servers[0].Node != "node-1" {
+		t.Fatalf("unexpected servers: %+v", servers)
+	}
+	id := servers[0].Identifier
+
+	var apiErr *alligator.ApiError
+	if err = client.SendServerCommand(id, "say hi"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected a 502 for an offline server, got %v", err)
+	}
+	if err = client.SetServerPowerState(id, "start"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.SendServerCommand(id, "say hi"); err != nil {
+		t.Fatal(err)
+	}
+	if p.PowerState(id) != "running" || len(p.Commands(id)) != 1 {
+		t.Errorf("expected a running server with one command, got %s %v", p.PowerState(id), p.Commands(id))
+	}
+
+	if _, err = client.CreateDatabase(id, "%", "world"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateDatabase(id, "%", "extra"); err == nil {
+		t.Error("expected the database limit to be enforced")
+	}
+	if dbs, err := client.GetServerDatabases(id); err != nil || len(dbs) != 1 || dbs[0].Name != "s1_world" {
+		t.Errorf("unexpected databases: %v %+v", err, dbs)
+	}
+
+	if err = client.WriteServerFile(id, "config/server.properties", "motd=hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.CompressServerFiles(id, alligator.CompressDescriptor{Root: "/", Files: []string{"config"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.DeleteServerFiles(id, alligator.DeleteFilesDescriptor{Root: "/", Files: []string{"config"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := client.GetServerFiles(id, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the archive, got %d files", len(files))
+	}
+	if err = client.DecompressServerFile(id, alligator.DecompressDescriptor{Root: "/", File: files[0].Name}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := client.GetServerFileContents(id, "config/server.properties")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "motd=hello" {
+		t.Errorf("unexpected file contents after decompressing: %q", data)
+	}
+
+	path := filepath.Join(t.TempDir(), "world.zip")
+	os.WriteFile(path, []byte("zip"), 0o644)
+	up, err := client.UploadServerFile(id, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = up.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := p.File(id, "world.zip"); string(data) != "zip" {
+		t.Errorf("uploaded file was not stored: %q", data)
+	}
+
+	other, _ := alligator.NewClient(p.URL, p.ClientKey(3))
+	if _, err = other.GetServers(); !errors.Is(err, alligator.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for an unknown user, got %v", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	p := NewPanel()
+	defer p.Close()
+	p.Seed(DefaultFixtures())
+
+	retry := &alligator.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	app, _ := alligator.NewApp(p.URL, p.AppKey, alligator.WithRetry(retry), alligator.WithRateLimiter(nil))
+
+	p.Inject(Fault{Method: "GET", Path: "/api/application/users", Status: http.StatusServiceUnavailable, Times: 2})
+	if _, err := app.GetUser(1); err != nil {
+		t.Fatalf("expected the request to be retried past the faults, got %v", err)
+	}
+
+	p.Inject(Fault{Status: http.StatusTooManyRequests, Times: 3})
+	if _, err := app.GetUser(1); !errors.Is(err, alligator.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	p.Inject(Fault{Latency: time.Second})
+	app.Http = &http.Client{Timeout: 50 * time.Millisecond}
+	app.Retry = nil
+	if _, err := app.GetUser(1); err == nil {
+		t.Error("expected the request to time out")
+	}
+	p.ClearFaults()
+}
@@ -0,0 +1,220 @@
+package alligatortest
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// console is a websocket connected to the Wings stand-in.
+type console struct {
+	mu     sync.Mutex // Serialises writes
+	conn   *websocket.Conn
+	server *server
+	authed bool
+}
+
+func (c *console) send(event string, args ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	msg := document{"event": event}
+	if len(args) > 0 {
+		msg["args"] = args
+	}
+	c.conn.WriteJSON(msg)
+}
+
+func (p *Panel) wingsRoutes(mux *http.ServeMux) {
+	upgrader := websocket.Upgrader{
+		// Wings only accepts connections from the panel
+		CheckOrigin: func(r *http.Request) bool {
+			return r.Header.Get("Origin") == p.URL
+		},
+	}
+
+	mux.HandleFunc("GET /api/servers/{uuid}/ws", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		s := p.serverByIdentifier(r.PathValue("uuid"))
+		p.mu.Unlock()
+		if s == nil {
+			notFound(w)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		c := &console{conn: conn, server: s}
+		p.mu.Lock()
+		s.consoles = append(s.consoles, c)
+		p.mu.Unlock()
+
+		defer func() {
+			p.mu.Lock()
+			for i, sc := range s.consoles {
+				if sc == c {
+					s.consoles = append(s.consoles[:i], s.consoles[i+1:]...)
+					break
+				}
+			}
+			p.mu.Unlock()
+			conn.Close()
+		}()
+
+		for {
+			var msg struct {
+				Event string   `json:"event"`
+				Args  []string `json:"args"`
+			}
+			if err = conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			p.mu.Lock()
+			p.consoleMessage(c, msg.Event, msg.Args)
+			p.mu.Unlock()
+		}
+	})
+}
+
+func (p *Panel) consoleMessage(c *console, event string, args []string) {
+	s := c.server
+	if event == "auth" {
+		if len(args) == 0 || p.socketTokens[args[0]] != s.ID {
+			c.authed = false
+			c.send("jwt error", "jwt: invalid token")
+			return
+		}
+		c.authed = true
+		c.send("auth success")
+		c.send("status", s.state)
+		return
+	}
+	if !c.authed {
+		c.send("jwt error", "jwt: not authenticated")
+		return
+	}
+
+	switch event {
+	case "send command":
+		if len(args) > 0 && s.state == "running" {
+			s.commands = append(s.commands, args[0])
+		}
+	case "set state":
+		if len(args) > 0 {
+			p.power(s, args[0])
+		}
+	case "send logs":
+		for _, line := range s.output {
+			c.send("console output", line)
+		}
+	case "send stats":
+		c.send("stats", p.consoleStats(s))
+	}
+}
+
+func (p *Panel) consoleStats(s *server) string {
+	stats := document{"state": s.state, "memory_limit_bytes": s.Limits.Memory << 20}
+	if s.state == "running" {
+		stats["memory_bytes"] = s.Limits.Memory << 19 // Half of the limit in MiB, like GetServerResources
+		stats["cpu_absolute"] = 12.5
+		stats["uptime"] = time.Since(s.started).Milliseconds()
+	}
+	buf, _ := json.Marshal(stats)
+	return string(buf)
+}
+
+// power applies a power signal and tells the connected consoles about the new state.
+func (p *Panel) power(s *server, signal string) bool {
+	switch signal {
+	case "start", "restart":
+		s.state, s.started = "running", time.Now()
+	case "stop", "kill":
+		s.state = "offline"
+	default:
+		return false
+	}
+	p.broadcast(s, "status", s.state)
+	return true
+}
+
+func (p *Panel) broadcast(s *server, event string, args ...string) {
+	if event == "console output" {
+		s.output = append(s.output, args...)
+	}
+	for _, c := range s.consoles {
+		if c.authed {
+			c.send(event, args...)
+		}
+	}
+}
+
+func (p *Panel) socketURL(s *server) string {
+	return "ws" + strings.TrimPrefix(p.URL, "http") + "/api/servers/" + s.UUID + "/ws"
+}
+
+// ConsoleEvent sends an event such as "console output" or "token expiring" to the consoles of a server.
+// Console output is kept and replayed when a console asks for the logs.
+func (p *Panel) ConsoleEvent(identifier, event string, args ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.serverByIdentifier(identifier); s != nil {
+		p.broadcast(s, event, args...)
+	}
+}
+
+// ExpireConsoleTokens revokes the websocket tokens of a server and sends "token expired" to its consoles,
+// which have to authenticate again with a new token.
+func (p *Panel) ExpireConsoleTokens(identifier string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := p.serverByIdentifier(identifier)
+	if s == nil {
+		return
+	}
+	for token, id := range p.socketTokens {
+		if id == s.ID {
+			delete(p.socketTokens, token)
+		}
+	}
+	for _, c := range s.consoles {
+		if c.authed {
+			c.authed = false
+			c.send("token expired")
+		}
+	}
+}
+
+// DropConsoles closes the websocket connections of a server, as if Wings restarted.
+func (p *Panel) DropConsoles(identifier string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.serverByIdentifier(identifier); s != nil {
+		for _, c := range s.consoles {
+			c.conn.Close()
+		}
+	}
+}
+
+// Consoles returns the number of authenticated consoles connected to a server.
+func (p *Panel) Consoles(identifier string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var n int
+	if s := p.serverByIdentifier(identifier); s != nil {
+		for _, c := range s.consoles {
+			if c.authed {
+				n++
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,299 @@
+package alligator
+
+import (
+	"context"
+	"github.com/m41denx/alligator/options"
+)
+
+// ApplicationAPI is the method set of Application, depend on it to swap the client for a mock in tests.
+type ApplicationAPI interface {
+	// Users
+	ListUsers(opts ...options.ListUsersOptions) ([]*User, error)
+	ListUsersCtx(ctx context.Context, opts ...options.ListUsersOptions) ([]*User, error)
+	ListUsersPages(opts ...options.ListUsersOptions) *Pager[*User]
+	ListAllUsers(opts ...options.ListUsersOptions) ([]*User, error)
+	ListAllUsersCtx(ctx context.Context, opts ...options.ListUsersOptions) ([]*User, error)
+	GetUser(id int, opts ...options.GetUserOptions) (*User, error)
+	GetUserCtx(ctx context.Context, id int, opts ...options.GetUserOptions) (*User, error)
+	GetUserExternal(id string, opts ...options.GetUserOptions) (*User, error)
+	GetUserExternalCtx(ctx context.Context, id string, opts ...options.GetUserOptions) (*User, error)
+	CreateUser(fields CreateUserDescriptor) (*User, error)
+	CreateUserCtx(ctx context.Context, fields CreateUserDescriptor) (*User, error)
+	UpdateUser(id int, fields UpdateUserDescriptor) (*User, error)
+	UpdateUserCtx(ctx context.Context, id int, fields UpdateUserDescriptor) (*User, error)
+	DeleteUser(id int) error
+	DeleteUserCtx(ctx context.Context, id int) error
+
+	// Locations
+	ListLocations(opts ...options.ListLocationsOptions) ([]*Location, error)
+	ListLocationsCtx(ctx context.Context, opts ...options.ListLocationsOptions) ([]*Location, error)
+	ListLocationsPages(opts ...options.ListLocationsOptions) *Pager[*Location]
+	ListAllLocations(opts ...options.ListLocationsOptions) ([]*Location, error)
+	ListAllLocationsCtx(ctx context.Context, opts ...options.ListLocationsOptions) ([]*Location, error)
+	GetLocation(id int, opts ...options.GetLocationOptions) (*Location, error)
+	GetLocationCtx(ctx context.Context, id int, opts ...options.GetLocationOptions) (*Location, error)
+	CreateLocation(short, long string) (*Location, error)
+	CreateLocationCtx(ctx context.Context, short, long string) (*Location, error)
+	UpdateLocation(id int, short, long string) (*Location, error)
+	UpdateLocationCtx(ctx context.Context, id int, short, long string) (*Location, error)
+	DeleteLocation(id int) error
+	DeleteLocationCtx(ctx context.Context, id int) error
+
+	// Nodes & allocations
+	ListNodes(opts ...options.ListNodesOptions) ([]*Node, error)
+	ListNodesCtx(ctx context.Context, opts ...options.ListNodesOptions) ([]*Node, error)
+	ListNodesPages(opts ...options.ListNodesOptions) *Pager[*Node]
+	ListAllNodes(opts ...options.ListNodesOptions) ([]*Node, error)
+	ListAllNodesCtx(ctx context.Context, opts ...options.ListNodesOptions) ([]*Node, error)
+	GetNode(id int, opts ...options.GetNodeOptions) (*Node, error)
+	GetNodeCtx(ctx context.Context, id int, opts ...options.GetNodeOptions) (*Node, error)
+	ListDeployableNodes(fields DeployableNodesDescriptor) ([]*Node, error)
+	ListDeployableNodesCtx(ctx context.Context, fields DeployableNodesDescriptor) ([]*Node, error)
+	ListDeployableNodesPages(fields DeployableNodesDescriptor) *Pager[*Node]
+	ListAllDeployableNodes(fields DeployableNodesDescriptor) ([]*Node, error)
+	ListAllDeployableNodesCtx(ctx context.Context, fields DeployableNodesDescriptor) ([]*Node, error)
+	GetNodeConfiguration(id int) (*NodeConfiguration, error)
+	GetNodeConfigurationCtx(ctx context.Context, id int) (*NodeConfiguration, error)
+	CreateNode(fields CreateNodeDescriptor) (*Node, error)
+	CreateNodeCtx(ctx context.Context, fields CreateNodeDescriptor) (*Node, error)
+	UpdateNode(id int, fields UpdateNodeDescriptor) (*Node, error)
+	UpdateNodeCtx(ctx context.Context, id int, fields UpdateNodeDescriptor) (*Node, error)
+	DeleteNode(id int) error
+	DeleteNodeCtx(ctx context.Context, id int) error
+	ListNodeAllocations(node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error)
+	ListNodeAllocationsCtx(ctx context.Context, node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error)
+	ListNodeAllocationsPages(node int, opts ...options.ListNodeAllocationsOptions) *Pager[*Allocation]
+	ListAllNodeAllocations(node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error)
+	ListAllNodeAllocationsCtx(ctx context.Context, node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error)
+	CreateNodeAllocations(node int, fields CreateAllocationsDescriptor) error
+	CreateNodeAllocationsCtx(ctx context.Context, node int, fields CreateAllocationsDescriptor) error
+	DeleteNodeAllocation(node, id int) error
+	DeleteNodeAllocationCtx(ctx context.Context, node, id int) error
+
+	// Servers
+	ListServers(opts ...options.ListServersOptions) ([]*AppServer, error)
+	ListServersCtx(ctx context.Context, opts ...options.ListServersOptions) ([]*AppServer, error)
+	ListServersPages(opts ...options.ListServersOptions) *Pager[*AppServer]
+	ListAllServers(opts ...options.ListServersOptions) ([]*AppServer, error)
+	ListAllServersCtx(ctx context.Context, opts ...options.ListServersOptions) ([]*AppServer, error)
+	GetServer(id int, opts ...options.GetServerOptions) (*AppServer, error)
+	GetServerCtx(ctx context.Context, id int, opts ...options.GetServerOptions) (*AppServer, error)
+	GetServerExternal(id string, opts ...options.GetServerOptions) (*AppServer, error)
+	GetServerExternalCtx(ctx context.Context, id string, opts ...options.GetServerOptions) (*AppServer, error)
+	CreateServer(fields CreateServerDescriptor) (*AppServer, error)
+	CreateServerCtx(ctx context.Context, fields CreateServerDescriptor) (*AppServer, error)
+	UpdateServerBuild(id int, fields ServerBuildDescriptor) (*AppServer, error)
+	UpdateServerBuildCtx(ctx context.Context, id int, fields ServerBuildDescriptor) (*AppServer, error)
+	UpdateServerDetails(id int, fields ServerDetailsDescriptor) (*AppServer, error)
+	UpdateServerDetailsCtx(ctx context.Context, id int, fields ServerDetailsDescriptor) (*AppServer, error)
+	UpdateServerStartup(id int, fields ServerStartupDescriptor) (*AppServer, error)
+	UpdateServerStartupCtx(ctx context.Context, id int, fields ServerStartupDescriptor) (*AppServer, error)
+	SuspendServer(id int) error
+	SuspendServerCtx(ctx context.Context, id int) error
+	UnsuspendServer(id int) error
+	UnsuspendServerCtx(ctx context.Context, id int) error
+	ReinstallServer(id int) error
+	ReinstallServerCtx(ctx context.Context, id int) error
+	DeleteServer(id int, force bool) error
+	DeleteServerCtx(ctx context.Context, id int, force bool) error
+
+	// Server databases
+	ListServerDatabases(server int, opts ...options.ListDatabasesOptions) ([]*AppDatabase, error)
+	ListServerDatabasesCtx(ctx context.Context, server int, opts ...options.ListDatabasesOptions) ([]*AppDatabase, error)
+	GetServerDatabase(server, id int, opts ...options.GetDatabaseOptions) (*AppDatabase, error)
+	GetServerDatabaseCtx(ctx context.Context, server, id int, opts ...options.GetDatabaseOptions) (*AppDatabase, error)
+	CreateServerDatabase(server int, fields CreateDatabaseDescriptor) (*AppDatabase, error)
+	CreateServerDatabaseCtx(ctx context.Context, server int, fields CreateDatabaseDescriptor) (*AppDatabase, error)
+	ResetServerDatabasePassword(server, id int) error
+	ResetServerDatabasePasswordCtx(ctx context.Context, server, id int) error
+	DeleteServerDatabase(server, id int) error
+	DeleteServerDatabaseCtx(ctx context.Context, server, id int) error
+
+	// Mounts
+	ListMounts(opts ...options.ListMountsOptions) ([]*Mount, error)
+	ListMountsCtx(ctx context.Context, opts ...options.ListMountsOptions) ([]*Mount, error)
+	ListMountsPages(opts ...options.ListMountsOptions) *Pager[*Mount]
+	ListAllMounts(opts ...options.ListMountsOptions) ([]*Mount, error)
+	ListAllMountsCtx(ctx context.Context, opts ...options.ListMountsOptions) ([]*Mount, error)
+	GetMount(id int, opts ...options.GetMountOptions) (*Mount, error)
+	GetMountCtx(ctx context.Context, id int, opts ...options.GetMountOptions) (*Mount, error)
+	CreateMount(fields MountDescriptor) (*Mount, error)
+	CreateMountCtx(ctx context.Context, fields MountDescriptor) (*Mount, error)
+	UpdateMount(id int, fields MountDescriptor) (*Mount, error)
+	UpdateMountCtx(ctx context.Context, id int, fields MountDescriptor) (*Mount, error)
+	DeleteMount(id int) error
+	DeleteMountCtx(ctx context.Context, id int) error
+	AttachMountEggs(id int, eggs []int) (*Mount, error)
+	AttachMountEggsCtx(ctx context.Context, id int, eggs []int) (*Mount, error)
+	DetachMountEgg(id, egg int) error
+	DetachMountEggCtx(ctx context.Context, id, egg int) error
+	AttachMountNodes(id int, nodes []int) (*Mount, error)
+	AttachMountNodesCtx(ctx context.Context, id int, nodes []int) (*Mount, error)
+	DetachMountNode(id, node int) error
+	DetachMountNodeCtx(ctx context.Context, id, node int) error
+
+	// Nests & eggs
+	ListNests(opts ...options.ListNestsOptions) ([]*Nest, error)
+	ListNestsCtx(ctx context.Context, opts ...options.ListNestsOptions) ([]*Nest, error)
+	ListNestsPages(opts ...options.ListNestsOptions) *Pager[*Nest]
+	ListAllNests(opts ...options.ListNestsOptions) ([]*Nest, error)
+	ListAllNestsCtx(ctx context.Context, opts ...options.ListNestsOptions) ([]*Nest, error)
+	GetNest(nestID int, opts ...options.GetNestOptions) (*Nest, error)
+	GetNestCtx(ctx context.Context, nestID int, opts ...options.GetNestOptions) (*Nest, error)
+	ListNestEggs(nestID int, opts ...options.ListEggsOptions) ([]*Egg, error)
+	ListNestEggsCtx(ctx context.Context, nestID int, opts ...options.ListEggsOptions) ([]*Egg, error)
+	ListNestEggsPages(nestID int, opts ...options.ListEggsOptions) *Pager[*Egg]
+	ListAllNestEggs(nestID int, opts ...options.ListEggsOptions) ([]*Egg, error)
+	ListAllNestEggsCtx(ctx context.Context, nestID int, opts ...options.ListEggsOptions) ([]*Egg, error)
+	GetEgg(nestID, eggID int, opts ...options.GetEggOptions) (*Egg, error)
+	GetEggCtx(ctx context.Context, nestID, eggID int, opts ...options.GetEggOptions) (*Egg, error)
+
+	Use(mws ...Middleware)
+}
+
+// ClientAPI is the method set of Client, depend on it to swap the client for a mock in tests.
+type ClientAPI interface {
+	// Account
+	GetAccount() (*Account, error)
+	GetAccountCtx(ctx context.Context) (*Account, error)
+	GetTwoFactor() (*TwoFactorData, error)
+	GetTwoFactorCtx(ctx context.Context) (*TwoFactorData, error)
+	EnableTwoFactor(code int) ([]string, error)
+	EnableTwoFactorCtx(ctx context.Context, code int) ([]string, error)
+	DisableTwoFactor(password string) error
+	DisableTwoFactorCtx(ctx context.Context, password string) error
+	UpdateEmail(email, password string) error
+	UpdateEmailCtx(ctx context.Context, email, password string) error
+	UpdatePassword(old, new string) error
+	UpdatePasswordCtx(ctx context.Context, old, new string) error
+	GetApiKeys() ([]*ApiKey, error)
+	GetApiKeysCtx(ctx context.Context) ([]*ApiKey, error)
+	CreateKey(description string, ips []string) (*ApiKey, error)
+	CreateKeyCtx(ctx context.Context, description string, ips []string) (*ApiKey, error)
+	DeleteKey(identifier string) error
+	DeleteKeyCtx(ctx context.Context, identifier string) error
+
+	// Servers
+	GetServers() ([]*ClientServer, error)
+	GetServersCtx(ctx context.Context) ([]*ClientServer, error)
+	GetServer(identifier string) (*ClientServer, error)
+	GetServerCtx(ctx context.Context, identifier string) (*ClientServer, error)
+	GetServerWebSocket(identifier string) (*WebSocketAuth, error)
+	GetServerWebSocketCtx(ctx context.Context, identifier string) (*WebSocketAuth, error)
+	OpenConsole(identifier string, opts ...ConsoleOptions) (*Console, error)
+	OpenConsoleCtx(ctx context.Context, identifier string, opts ...ConsoleOptions) (*Console, error)
+	GetServerResources(identifier string) (*Resources, error)
+	GetServerResourcesCtx(ctx context.Context, identifier string) (*Resources, error)
+	SendServerCommand(identifier, command string) error
+	SendServerCommandCtx(ctx context.Context, identifier, command string) error
+	SetServerPowerState(identifier, state string) error
+	SetServerPowerStateCtx(ctx context.Context, identifier, state string) error
+	GetServerDatabases(identifier string) ([]*ClientDatabase, error)
+	GetServerDatabasesCtx(ctx context.Context, identifier string) ([]*ClientDatabase, error)
+	CreateDatabase(identifier, remote, database string) (*ClientDatabase, error)
+	CreateDatabaseCtx(ctx context.Context, identifier, remote, database string) (*ClientDatabase, error)
+	RotateDatabasePassword(identifier, id string) (*ClientDatabase, error)
+	RotateDatabasePasswordCtx(ctx context.Context, identifier, id string) (*ClientDatabase, error)
+	DeleteDatabase(identifier, id string) error
+	DeleteDatabaseCtx(ctx context.Context, identifier, id string) error
+	GetServerFiles(identifier, root string) ([]*File, error)
+	GetServerFilesCtx(ctx context.Context, identifier, root string) ([]*File, error)
+	GetServerFileContents(identifier, file string) ([]byte, error)
+	GetServerFileContentsCtx(ctx context.Context, identifier, file string) ([]byte, error)
+	DownloadServerFile(identifier, file string) (*Downloader, error)
+	DownloadServerFileCtx(ctx context.Context, identifier, file string) (*Downloader, error)
+	RenameServerFiles(identifier string, files RenameDescriptor) error
+	RenameServerFilesCtx(ctx context.Context, identifier string, files RenameDescriptor) error
+	CopyServerFile(identifier, location string) error
+	CopyServerFileCtx(ctx context.Context, identifier, location string) error
+	WriteServerFileBytes(identifier, name, header string, content []byte) error
+	WriteServerFileBytesCtx(ctx context.Context, identifier, name, header string, content []byte) error
+	WriteServerFile(identifier, name, content string) error
+	WriteServerFileCtx(ctx context.Context, identifier, name, content string) error
+	CompressServerFiles(identifier string, files CompressDescriptor) error
+	CompressServerFilesCtx(ctx context.Context, identifier string, files CompressDescriptor) error
+	DecompressServerFile(identifier string, file DecompressDescriptor) error
+	DecompressServerFileCtx(ctx context.Context, identifier string, file DecompressDescriptor) error
+	DeleteServerFiles(identifier string, files DeleteFilesDescriptor) error
+	DeleteServerFilesCtx(ctx context.Context, identifier string, files DeleteFilesDescriptor) error
+	CreateServerFileFolder(identifier string, file CreateFolderDescriptor) error
+	CreateServerFileFolderCtx(ctx context.Context, identifier string, file CreateFolderDescriptor) error
+	ChmodServerFiles(identifier string, files ChmodDescriptor) error
+	ChmodServerFilesCtx(ctx context.Context, identifier string, files ChmodDescriptor) error
+	PullServerFile(identifier string, file PullDescriptor) error
+	PullServerFileCtx(ctx context.Context, identifier string, file PullDescriptor) error
+	UploadServerFile(identifier, path string) (*Uploader, error)
+	UploadServerFileCtx(ctx context.Context, identifier, path string) (*Uploader, error)
+
+	// Backups
+	ListBackups(identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error)
+	ListBackupsCtx(ctx context.Context, identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error)
+	ListBackupsPages(identifier string, opts ...options.ListBackupsOptions) *Pager[*Backup]
+	ListAllBackups(identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error)
+	ListAllBackupsCtx(ctx context.Context, identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error)
+	CreateBackup(identifier string, fields BackupDescriptor) (*Backup, error)
+	CreateBackupCtx(ctx context.Context, identifier string, fields BackupDescriptor) (*Backup, error)
+	GetBackup(identifier, uuid string) (*Backup, error)
+	GetBackupCtx(ctx context.Context, identifier, uuid string) (*Backup, error)
+	DownloadBackup(identifier, uuid string) (*Downloader, error)
+	DownloadBackupCtx(ctx context.Context, identifier, uuid string) (*Downloader, error)
+	RestoreBackup(identifier, uuid string, truncate bool) error
+	RestoreBackupCtx(ctx context.Context, identifier, uuid string, truncate bool) error
+	ToggleBackupLock(identifier, uuid string) (*Backup, error)
+	ToggleBackupLockCtx(ctx context.Context, identifier, uuid string) (*Backup, error)
+	DeleteBackup(identifier, uuid string) error
+	DeleteBackupCtx(ctx context.Context, identifier, uuid string) error
+
+	// Schedules
+	ListSchedules(identifier string) ([]*Schedule, error)
+	ListSchedulesCtx(ctx context.Context, identifier string) ([]*Schedule, error)
+	GetSchedule(identifier string, id int) (*Schedule, error)
+	GetScheduleCtx(ctx context.Context, identifier string, id int) (*Schedule, error)
+	CreateSchedule(identifier string, fields ScheduleDescriptor) (*Schedule, error)
+	CreateScheduleCtx(ctx context.Context, identifier string, fields ScheduleDescriptor) (*Schedule, error)
+	UpdateSchedule(identifier string, id int, fields ScheduleDescriptor) (*Schedule, error)
+	UpdateScheduleCtx(ctx context.Context, identifier string, id int, fields ScheduleDescriptor) (*Schedule, error)
+	ExecuteSchedule(identifier string, id int) error
+	ExecuteScheduleCtx(ctx context.Context, identifier string, id int) error
+	DeleteSchedule(identifier string, id int) error
+	DeleteScheduleCtx(ctx context.Context, identifier string, id int) error
+	CreateTask(identifier string, schedule int, fields TaskDescriptor) (*Task, error)
+	CreateTaskCtx(ctx context.Context, identifier string, schedule int, fields TaskDescriptor) (*Task, error)
+	UpdateTask(identifier string, schedule, id int, fields TaskDescriptor) (*Task, error)
+	UpdateTaskCtx(ctx context.Context, identifier string, schedule, id int, fields TaskDescriptor) (*Task, error)
+	DeleteTask(identifier string, schedule, id int) error
+	DeleteTaskCtx(ctx context.Context, identifier string, schedule, id int) error
+
+	// Subusers
+	ListSubusers(identifier string) ([]*Subuser, error)
+	ListSubusersCtx(ctx context.Context, identifier string) ([]*Subuser, error)
+	GetSubuser(identifier, uuid string) (*Subuser, error)
+	GetSubuserCtx(ctx context.Context, identifier, uuid string) (*Subuser, error)
+	CreateSubuser(identifier, email string, permissions []Permission) (*Subuser, error)
+	CreateSubuserCtx(ctx context.Context, identifier, email string, permissions []Permission) (*Subuser, error)
+	UpdateSubuser(identifier, uuid string, permissions []Permission) (*Subuser, error)
+	UpdateSubuserCtx(ctx context.Context, identifier, uuid string, permissions []Permission) (*Subuser, error)
+	DeleteSubuser(identifier, uuid string) error
+	DeleteSubuserCtx(ctx context.Context, identifier, uuid string) error
+	GetPermissions() (map[string]*PermissionGroup, error)
+	GetPermissionsCtx(ctx context.Context) (map[string]*PermissionGroup, error)
+
+	// Network
+	ListAllocations(identifier string) ([]*ClientAllocation, error)
+	ListAllocationsCtx(ctx context.Context, identifier string) ([]*ClientAllocation, error)
+	AssignAllocation(identifier string) (*ClientAllocation, error)
+	AssignAllocationCtx(ctx context.Context, identifier string) (*ClientAllocation, error)
+	SetPrimaryAllocation(identifier string, id int) (*ClientAllocation, error)
+	SetPrimaryAllocationCtx(ctx context.Context, identifier string, id int) (*ClientAllocation, error)
+	UpdateAllocationNotes(identifier string, id int, notes string) (*ClientAllocation, error)
+	UpdateAllocationNotesCtx(ctx context.Context, identifier string, id int, notes string) (*ClientAllocation, error)
+	DeleteAllocation(identifier string, id int) error
+	DeleteAllocationCtx(ctx context.Context, identifier string, id int) error
+
+	Use(mws ...Middleware)
+}
+
+var (
+	_ ApplicationAPI = (*Application)(nil)
+	_ ClientAPI      = (*Client)(nil)
+)
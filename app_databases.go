@@ -0,0 +1,189 @@
+package alligator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/m41denx/alligator/options"
+	"time"
+)
+
+type DatabaseHost struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Host      string     `json:"host"`
+	Port      int64      `json:"port"`
+	Username  string     `json:"username"`
+	NodeID    int        `json:"node"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type AppDatabase struct {
+	ID             int           `json:"id"`
+	ServerID       int           `json:"server"`
+	HostID         int           `json:"host"`
+	Database       string        `json:"database"`
+	Username       string        `json:"username"`
+	Remote         string        `json:"remote"`
+	MaxConnections int           `json:"max_connections"`
+	CreatedAt      *time.Time    `json:"created_at"`
+	UpdatedAt      *time.Time    `json:"updated_at,omitempty"`
+	Password       string        `json:"-"`
+	HostObject     *DatabaseHost `json:"-"`
+}
+
+type ResponseDatabase struct {
+	*AppDatabase
+	Relationships struct {
+		Password struct {
+			Attributes struct {
+				Password string `json:"password"`
+			} `json:"attributes"`
+		} `json:"password"`
+		Host struct {
+			Attributes *DatabaseHost `json:"attributes"`
+		} `json:"host"`
+	} `json:"relationships"`
+}
+
+func (r *ResponseDatabase) getDatabase() *AppDatabase {
+	db := r.AppDatabase
+	db.Password = r.Relationships.Password.Attributes.Password
+	db.HostObject = r.Relationships.Host.Attributes
+	return db
+}
+
+func (a *Application) ListServerDatabases(server int, opts ...options.ListDatabasesOptions) ([]*AppDatabase, error) {
+	return a.ListServerDatabasesCtx(context.Background(), server, opts...)
+}
+
+func (a *Application) ListServerDatabasesCtx(ctx context.Context, server int, opts ...options.ListDatabasesOptions) ([]*AppDatabase, error) {
+	var o string
+	if opts != nil && len(opts) > 0 {
+		o = options.ParseRequestOptions(&opts[0])
+	}
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/servers/%d/databases?%s", server, o), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *ResponseDatabase `json:"attributes"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	dbs := make([]*AppDatabase, 0, len(model.Data))
+	for _, d := range model.Data {
+		dbs = append(dbs, d.Attributes.getDatabase())
+	}
+
+	return dbs, nil
+}
+
+func (a *Application) GetServerDatabase(server, id int, opts ...options.GetDatabaseOptions) (*AppDatabase, error) {
+	return a.GetServerDatabaseCtx(context.Background(), server, id, opts...)
+}
+
+func (a *Application) GetServerDatabaseCtx(ctx context.Context, server, id int, opts ...options.GetDatabaseOptions) (*AppDatabase, error) {
+	var o string
+	if opts != nil && len(opts) > 0 {
+		o = options.ParseRequestOptions(&opts[0])
+	}
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/servers/%d/databases/%d?%s", server, id, o), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes ResponseDatabase `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getDatabase(), nil
+}
+
+type CreateDatabaseDescriptor struct {
+	Database string `json:"database"`
+	Remote   string `json:"remote"`
+	Host     int    `json:"host"`
+}
+
+func (a *Application) CreateServerDatabase(server int, fields CreateDatabaseDescriptor) (*AppDatabase, error) {
+	return a.CreateServerDatabaseCtx(context.Background(), server, fields)
+}
+
+func (a *Application) CreateServerDatabaseCtx(ctx context.Context, server int, fields CreateDatabaseDescriptor) (*AppDatabase, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/servers/%d/databases", server), &body)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes ResponseDatabase `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getDatabase(), nil
+}
+
+func (a *Application) ResetServerDatabasePassword(server, id int) error {
+	return a.ResetServerDatabasePasswordCtx(context.Background(), server, id)
+}
+
+func (a *Application) ResetServerDatabasePasswordCtx(ctx context.Context, server, id int) error {
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/servers/%d/databases/%d/reset-password", server, id), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+func (a *Application) DeleteServerDatabase(server, id int) error {
+	return a.DeleteServerDatabaseCtx(context.Background(), server, id)
+}
+
+func (a *Application) DeleteServerDatabaseCtx(ctx context.Context, server, id int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%d/databases/%d", server, id), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
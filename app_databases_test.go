@@ -0,0 +1,57 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"testing"
+)
+
+func TestServerDatabases(t *testing.T) {
+	p := newPanel(t)
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+
+	db, err := app.CreateServerDatabase(1, alligator.CreateDatabaseDescriptor{Database: "world", Remote: "%", Host: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Database != "s1_world" || db.ServerID != 1 {
+		t.Errorf("unexpected database: %+v", db)
+	}
+
+	db, err = app.GetServerDatabase(1, db.ID, options.GetDatabaseOptions{Include: options.IncludeDatabases{Password: true, Host: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Password == "" || db.HostObject == nil || db.HostObject.Port != 3306 {
+		t.Errorf("password and host were not included: %q %+v", db.Password, db.HostObject)
+	}
+
+	password := db.Password
+	if err = app.ResetServerDatabasePassword(1, db.ID); err != nil {
+		t.Fatal(err)
+	}
+	dbs, err := app.ListServerDatabases(1, options.ListDatabasesOptions{Include: options.IncludeDatabases{Password: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dbs) != 1 || dbs[0].Password == password {
+		t.Errorf("expected one database with a new password, got %+v", dbs)
+	}
+
+	srv, err := app.GetServer(1, options.GetServerOptions{Include: options.IncludeServers{Databases: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Databases) != 1 || srv.Databases[0].Database != "s1_world" {
+		t.Errorf("databases relationship was not decoded: %+v", srv.Databases)
+	}
+
+	if err = app.DeleteServerDatabase(1, db.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.GetServerDatabase(1, db.ID); !errors.Is(err, alligator.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package alligator
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/m41denx/alligator/options"
@@ -21,34 +22,87 @@ type Nest struct {
 }
 
 type Egg struct {
-	ID          int            `json:"id,omitempty"`
-	UUID        string         `json:"uuid,omitempty"`
-	Name        string         `json:"name,omitempty"`
-	NestID      int            `json:"nest,omitempty"`
-	Author      string         `json:"author,omitempty"`
-	Description string         `json:"description,omitempty"`
-	DockerImage string         `json:"docker_image,omitempty"`
-	Config      EggConfig      `json:"config,omitempty"`
-	Startup     string         `json:"startup,omitempty"`
-	Script      EggScript      `json:"script,omitempty"`
-	CreatedAt   time.Time      `json:"created_at,omitempty"`
-	UpdatedAt   time.Time      `json:"updated_at,omitempty"`
-	NestObject  *Nest          `json:"-"`
-	Servers     []*AppServer   `json:"-"`
-	Variables   []*EggVariable `json:"-"`
+	ID           int               `json:"id,omitempty"`
+	UUID         string            `json:"uuid,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	NestID       int               `json:"nest,omitempty"`
+	Author       string            `json:"author,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	DockerImage  string            `json:"docker_image,omitempty"`
+	DockerImages map[string]string `json:"docker_images,omitempty"` // Display name => image
+	Features     []string          `json:"features,omitempty"`      // Only set by ParseEggExport, the API doesn't return them
+	Config       EggConfig         `json:"config,omitempty"`
+	Startup      string            `json:"startup,omitempty"`
+	Script       EggScript         `json:"script,omitempty"`
+	UpdateURL    string            `json:"-"` // Only set by ParseEggExport
+	CreatedAt    time.Time         `json:"created_at,omitempty"`
+	UpdatedAt    time.Time         `json:"updated_at,omitempty"`
+	NestObject   *Nest             `json:"-"`
+	Servers      []*AppServer      `json:"-"`
+	Variables    []*EggVariable    `json:"-"`
 }
 
 type EggConfig struct {
-	Files   map[string]EggFileConfig `json:"files,omitempty"`
-	Startup EggStartup               `json:"startup,omitempty"`
-	Stop    string                   `json:"stop,omitempty"`
-	Logs    EggLogs                  `json:"logs,omitempty"`
-	Extends interface{}              `json:"extends,omitempty"`
+	Files        map[string]EggFileConfig `json:"files,omitempty"`
+	Startup      EggStartup               `json:"startup,omitempty"`
+	Stop         string                   `json:"stop,omitempty"`
+	Logs         EggLogs                  `json:"logs,omitempty"`
+	FileDenylist []string                 `json:"file_denylist,omitempty"`
+	Extends      interface{}              `json:"extends,omitempty"`
 }
 
+// EggFileConfig is how Wings edits a config file. Find replaces the value of each key, Match only replaces
+// it when it equals one of the matches (match => replacement), both are encoded in "find".
 type EggFileConfig struct {
-	Parser string            `json:"parser,omitempty"`
-	Find   map[string]string `json:"find,omitempty"`
+	Parser string
+	Find   map[string]string
+	Match  map[string]map[string]string
+}
+
+func (c *EggFileConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Parser string                     `json:"parser"`
+		Find   map[string]json.RawMessage `json:"find"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Parser, c.Find, c.Match = raw.Parser, nil, nil
+	for key, value := range raw.Find {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			if c.Find == nil {
+				c.Find = make(map[string]string)
+			}
+			c.Find[key] = s
+			continue
+		}
+
+		var m map[string]string
+		if err := json.Unmarshal(value, &m); err != nil {
+			return fmt.Errorf("find %s: %w", key, err)
+		}
+		if c.Match == nil {
+			c.Match = make(map[string]map[string]string)
+		}
+		c.Match[key] = m
+	}
+	return nil
+}
+
+func (c EggFileConfig) MarshalJSON() ([]byte, error) {
+	find := make(map[string]interface{}, len(c.Find)+len(c.Match))
+	for key, value := range c.Find {
+		find[key] = value
+	}
+	for key, value := range c.Match {
+		find[key] = value
+	}
+	return json.Marshal(struct {
+		Parser string                 `json:"parser,omitempty"`
+		Find   map[string]interface{} `json:"find,omitempty"`
+	}{c.Parser, find})
 }
 
 type EggStartup struct {
@@ -99,6 +153,13 @@ type EggVariable struct {
 	UpdatedAt    string `json:"updated_at,omitempty"`
 }
 
+// ServerVariable is an egg variable as seen by a server: DefaultValue comes from the egg,
+// ServerValue is what the server currently has it set to.
+type ServerVariable struct {
+	EggVariable
+	ServerValue string `json:"server_value"`
+}
+
 type ResponseNest struct {
 	*Nest
 	Relationships struct {
@@ -129,47 +190,83 @@ func (r *ResponseNest) getNest() *Nest {
 }
 
 func (a *Application) ListNests(opts ...options.ListNestsOptions) ([]*Nest, error) {
+	return a.ListNestsCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListNestsCtx(ctx context.Context, opts ...options.ListNestsOptions) ([]*Nest, error) {
+	nests, _, err := a.listNests(ctx, opts...)
+	return nests, err
+}
+
+func (a *Application) ListNestsPages(opts ...options.ListNestsOptions) *Pager[*Nest] {
+	var o options.ListNestsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Nest, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listNests(ctx, o)
+	})
+}
+
+func (a *Application) ListAllNests(opts ...options.ListNestsOptions) ([]*Nest, error) {
+	return a.ListAllNestsCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListAllNestsCtx(ctx context.Context, opts ...options.ListNestsOptions) ([]*Nest, error) {
+	return a.ListNestsPages(opts...).All(ctx)
+}
+
+func (a *Application) listNests(ctx context.Context, opts ...options.ListNestsOptions) ([]*Nest, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/nests?%s", o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nests?%s", o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var model struct {
 		Data []struct {
 			Attributes *ResponseNest `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 
 	err = json.Unmarshal(buf, &model)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	nests := make([]*Nest, len(model.Data))
+	nests := make([]*Nest, 0, len(model.Data))
 	for _, nest := range model.Data {
 		nests = append(nests, nest.Attributes.getNest())
 	}
 
-	return nests, nil
+	return nests, model.Meta.Pagination, nil
 }
 
 func (a *Application) GetNest(nestID int, opts ...options.GetNestOptions) (*Nest, error) {
+	return a.GetNestCtx(context.Background(), nestID, opts...)
+}
+
+func (a *Application) GetNestCtx(ctx context.Context, nestID int, opts ...options.GetNestOptions) (*Nest, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("nests/%d?%s", nestID, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nests/%d?%s", nestID, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -225,47 +322,83 @@ func (r *ResponseEgg) getEgg() *Egg {
 }
 
 func (a *Application) ListNestEggs(nestID int, opts ...options.ListEggsOptions) ([]*Egg, error) {
+	return a.ListNestEggsCtx(context.Background(), nestID, opts...)
+}
+
+func (a *Application) ListNestEggsCtx(ctx context.Context, nestID int, opts ...options.ListEggsOptions) ([]*Egg, error) {
+	eggs, _, err := a.listNestEggs(ctx, nestID, opts...)
+	return eggs, err
+}
+
+func (a *Application) ListNestEggsPages(nestID int, opts ...options.ListEggsOptions) *Pager[*Egg] {
+	var o options.ListEggsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Egg, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listNestEggs(ctx, nestID, o)
+	})
+}
+
+func (a *Application) ListAllNestEggs(nestID int, opts ...options.ListEggsOptions) ([]*Egg, error) {
+	return a.ListAllNestEggsCtx(context.Background(), nestID, opts...)
+}
+
+func (a *Application) ListAllNestEggsCtx(ctx context.Context, nestID int, opts ...options.ListEggsOptions) ([]*Egg, error) {
+	return a.ListNestEggsPages(nestID, opts...).All(ctx)
+}
+
+func (a *Application) listNestEggs(ctx context.Context, nestID int, opts ...options.ListEggsOptions) ([]*Egg, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("nests/%d/eggs?%s", nestID, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nests/%d/eggs?%s", nestID, o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var model struct {
 		Data []struct {
 			Attributes *ResponseEgg `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 
 	err = json.Unmarshal(buf, &model)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	eggs := make([]*Egg, len(model.Data))
+	eggs := make([]*Egg, 0, len(model.Data))
 	for _, egg := range model.Data {
 		eggs = append(eggs, egg.Attributes.getEgg())
 	}
 
-	return eggs, nil
+	return eggs, model.Meta.Pagination, nil
 }
 
 func (a *Application) GetEgg(nestID, eggID int, opts ...options.GetEggOptions) (*Egg, error) {
+	return a.GetEggCtx(context.Background(), nestID, eggID, opts...)
+}
+
+func (a *Application) GetEggCtx(ctx context.Context, nestID, eggID int, opts ...options.GetEggOptions) (*Egg, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("nests/%d/eggs?%s", nestID, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nests/%d/eggs/%d?%s", nestID, eggID, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
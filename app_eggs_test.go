@@ -0,0 +1,40 @@
+package alligator_test
+
+import (
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"os"
+	"testing"
+)
+
+func TestEggDrift(t *testing.T) {
+	p := newPanel(t)
+
+	f, err := os.Open("testdata/egg-paper.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	local, err := alligator.ParseEggExport(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local.NestID = 1
+	id := p.AddEgg(local).ID
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	remote, err := app.GetEgg(1, id, options.GetEggOptions{Include: options.IncludeEggs{Variables: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift := alligator.CompareEggs(local, remote); len(drift) > 0 {
+		t.Errorf("expected no drift after import, got %v", drift)
+	}
+
+	local.Startup = "java -jar {{SERVER_JARFILE}}"
+	local.Variables[0].DefaultValue = "paper.jar"
+	drift := alligator.CompareEggs(local, remote)
+	if len(drift) != 2 || drift[0].Field != "startup" || drift[1].Field != "variables.SERVER_JARFILE.default_value" {
+		t.Errorf("unexpected drift: %v", drift)
+	}
+}
@@ -2,6 +2,7 @@ package alligator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/m41denx/alligator/options"
@@ -48,28 +49,63 @@ func (r *ResponseLocation) getLocation() *Location {
 }
 
 func (a *Application) ListLocations(opts ...options.ListLocationsOptions) ([]*Location, error) {
+	return a.ListLocationsCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListLocationsCtx(ctx context.Context, opts ...options.ListLocationsOptions) ([]*Location, error) {
+	locs, _, err := a.listLocations(ctx, opts...)
+	return locs, err
+}
+
+func (a *Application) ListLocationsPages(opts ...options.ListLocationsOptions) *Pager[*Location] {
+	var o options.ListLocationsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Location, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listLocations(ctx, o)
+	})
+}
+
+func (a *Application) ListAllLocations(opts ...options.ListLocationsOptions) ([]*Location, error) {
+	return a.ListAllLocationsCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListAllLocationsCtx(ctx context.Context, opts ...options.ListLocationsOptions) ([]*Location, error) {
+	return a.ListLocationsPages(opts...).All(ctx)
+}
+
+func (a *Application) listLocations(ctx context.Context, opts ...options.ListLocationsOptions) ([]*Location, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
+		if err := options.ValidateRequestOptions(&opts[0]); err != nil {
+			return nil, nil, err
+		}
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/locations?%s", o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/locations?%s", o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var model struct {
 		Data []struct {
 			Attributes *ResponseLocation `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	locs := make([]*Location, 0, len(model.Data))
@@ -77,16 +113,20 @@ func (a *Application) ListLocations(opts ...options.ListLocationsOptions) ([]*Lo
 		locs = append(locs, l.Attributes.getLocation())
 	}
 
-	return locs, nil
+	return locs, model.Meta.Pagination, nil
 }
 
 func (a *Application) GetLocation(id int, opts ...options.GetLocationOptions) (*Location, error) {
+	return a.GetLocationCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetLocationCtx(ctx context.Context, id int, opts ...options.GetLocationOptions) (*Location, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/locations/%d?%s", id, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/locations/%d?%s", id, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -107,12 +147,16 @@ func (a *Application) GetLocation(id int, opts ...options.GetLocationOptions) (*
 }
 
 func (a *Application) CreateLocation(short, long string) (*Location, error) {
+	return a.CreateLocationCtx(context.Background(), short, long)
+}
+
+func (a *Application) CreateLocationCtx(ctx context.Context, short, long string) (*Location, error) {
 	data, _ := json.Marshal(map[string]string{"short": short, "long": long})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("POST", "/locations", &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "POST", "/locations", &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -133,12 +177,16 @@ func (a *Application) CreateLocation(short, long string) (*Location, error) {
 }
 
 func (a *Application) UpdateLocation(id int, short, long string) (*Location, error) {
+	return a.UpdateLocationCtx(context.Background(), id, short, long)
+}
+
+func (a *Application) UpdateLocationCtx(ctx context.Context, id int, short, long string) (*Location, error) {
 	data, _ := json.Marshal(map[string]string{"short": short, "long": long})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("PATCH", fmt.Sprintf("/locations/%d", id), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/locations/%d", id), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -159,8 +207,12 @@ func (a *Application) UpdateLocation(id int, short, long string) (*Location, err
 }
 
 func (a *Application) DeleteLocation(id int) error {
-	req := a.newRequest("DELETE", fmt.Sprintf("/locations/%d", id), nil)
-	res, err := a.Http.Do(req)
+	return a.DeleteLocationCtx(context.Background(), id)
+}
+
+func (a *Application) DeleteLocationCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/locations/%d", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,346 @@
+package alligator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/m41denx/alligator/options"
+)
+
+type Mount struct {
+	ID            int          `json:"id"`
+	UUID          string       `json:"uuid"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	Source        string       `json:"source"`
+	Target        string       `json:"target"`
+	ReadOnly      bool         `json:"read_only"`
+	UserMountable bool         `json:"user_mountable"`
+	Eggs          []*Egg       `json:"-"`
+	Nodes         []*Node      `json:"-"`
+	Servers       []*AppServer `json:"-"`
+}
+
+func (m *Mount) UpdateDescriptor() *MountDescriptor {
+	return &MountDescriptor{
+		Name:          m.Name,
+		Description:   Ptr(m.Description),
+		Source:        m.Source,
+		Target:        m.Target,
+		ReadOnly:      m.ReadOnly,
+		UserMountable: m.UserMountable,
+	}
+}
+
+type ResponseMount struct {
+	*Mount
+	Relationships struct {
+		Eggs struct {
+			Data []struct {
+				Attributes *Egg `json:"attributes"`
+			} `json:"data"`
+		} `json:"eggs"`
+		Nodes struct {
+			Data []struct {
+				Attributes *Node `json:"attributes"`
+			} `json:"data"`
+		} `json:"nodes"`
+		Servers struct {
+			Data []struct {
+				Attributes *AppServer `json:"attributes"`
+			} `json:"data"`
+		} `json:"servers"`
+	} `json:"relationships"`
+}
+
+func (r *ResponseMount) getMount() *Mount {
+	mount := r.Mount
+	mount.Eggs = make([]*Egg, 0)
+	for _, e := range r.Relationships.Eggs.Data {
+		mount.Eggs = append(mount.Eggs, e.Attributes)
+	}
+	mount.Nodes = make([]*Node, 0)
+	for _, n := range r.Relationships.Nodes.Data {
+		mount.Nodes = append(mount.Nodes, n.Attributes)
+	}
+	mount.Servers = make([]*AppServer, 0)
+	for _, s := range r.Relationships.Servers.Data {
+		mount.Servers = append(mount.Servers, s.Attributes)
+	}
+	return mount
+}
+
+func (a *Application) ListMounts(opts ...options.ListMountsOptions) ([]*Mount, error) {
+	return a.ListMountsCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListMountsCtx(ctx context.Context, opts ...options.ListMountsOptions) ([]*Mount, error) {
+	mounts, _, err := a.listMounts(ctx, opts...)
+	return mounts, err
+}
+
+func (a *Application) ListMountsPages(opts ...options.ListMountsOptions) *Pager[*Mount] {
+	var o options.ListMountsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Mount, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listMounts(ctx, o)
+	})
+}
+
+func (a *Application) ListAllMounts(opts ...options.ListMountsOptions) ([]*Mount, error) {
+	return a.ListAllMountsCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListAllMountsCtx(ctx context.Context, opts ...options.ListMountsOptions) ([]*Mount, error) {
+	return a.ListMountsPages(opts...).All(ctx)
+}
+
+func (a *Application) listMounts(ctx context.Context, opts ...options.ListMountsOptions) ([]*Mount, *Pagination, error) {
+	var o string
+	if opts != nil && len(opts) > 0 {
+		if err := options.ValidateRequestOptions(&opts[0]); err != nil {
+			return nil, nil, err
+		}
+		o = options.ParseRequestOptions(&opts[0])
+	}
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/mounts?%s", o), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *ResponseMount `json:"attributes"`
+		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, nil, err
+	}
+
+	mounts := make([]*Mount, 0, len(model.Data))
+	for _, m := range model.Data {
+		mounts = append(mounts, m.Attributes.getMount())
+	}
+
+	return mounts, model.Meta.Pagination, nil
+}
+
+func (a *Application) GetMount(id int, opts ...options.GetMountOptions) (*Mount, error) {
+	return a.GetMountCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetMountCtx(ctx context.Context, id int, opts ...options.GetMountOptions) (*Mount, error) {
+	var o string
+	if opts != nil && len(opts) > 0 {
+		o = options.ParseRequestOptions(&opts[0])
+	}
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/mounts/%d?%s", id, o), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseMount `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getMount(), nil
+}
+
+type MountDescriptor struct {
+	Name          string  `json:"name"`
+	Description   *string `json:"description,omitempty"`
+	Source        string  `json:"source"`
+	Target        string  `json:"target"`
+	ReadOnly      bool    `json:"read_only"`
+	UserMountable bool    `json:"user_mountable"`
+}
+
+func (a *Application) CreateMount(fields MountDescriptor) (*Mount, error) {
+	return a.CreateMountCtx(context.Background(), fields)
+}
+
+func (a *Application) CreateMountCtx(ctx context.Context, fields MountDescriptor) (*Mount, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := a.newRequest(ctx, "POST", "/mounts", &body)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseMount `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getMount(), nil
+}
+
+func (a *Application) UpdateMount(id int, fields MountDescriptor) (*Mount, error) {
+	return a.UpdateMountCtx(context.Background(), id, fields)
+}
+
+func (a *Application) UpdateMountCtx(ctx context.Context, id int, fields MountDescriptor) (*Mount, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/mounts/%d", id), &body)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseMount `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getMount(), nil
+}
+
+func (a *Application) DeleteMount(id int) error {
+	return a.DeleteMountCtx(context.Background(), id)
+}
+
+func (a *Application) DeleteMountCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/mounts/%d", id), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+// AttachMountEggs makes the mount available to servers using any of the eggs.
+func (a *Application) AttachMountEggs(id int, eggs []int) (*Mount, error) {
+	return a.AttachMountEggsCtx(context.Background(), id, eggs)
+}
+
+func (a *Application) AttachMountEggsCtx(ctx context.Context, id int, eggs []int) (*Mount, error) {
+	data, _ := json.Marshal(map[string][]int{"eggs": eggs})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/mounts/%d/eggs", id), &body)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseMount `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getMount(), nil
+}
+
+func (a *Application) DetachMountEgg(id, egg int) error {
+	return a.DetachMountEggCtx(context.Background(), id, egg)
+}
+
+func (a *Application) DetachMountEggCtx(ctx context.Context, id, egg int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/mounts/%d/eggs/%d", id, egg), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+// AttachMountNodes makes the mount available on the nodes.
+func (a *Application) AttachMountNodes(id int, nodes []int) (*Mount, error) {
+	return a.AttachMountNodesCtx(context.Background(), id, nodes)
+}
+
+func (a *Application) AttachMountNodesCtx(ctx context.Context, id int, nodes []int) (*Mount, error) {
+	data, _ := json.Marshal(map[string][]int{"nodes": nodes})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/mounts/%d/nodes", id), &body)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseMount `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getMount(), nil
+}
+
+func (a *Application) DetachMountNode(id, node int) error {
+	return a.DetachMountNodeCtx(context.Background(), id, node)
+}
+
+func (a *Application) DetachMountNodeCtx(ctx context.Context, id, node int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/mounts/%d/nodes/%d", id, node), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
@@ -0,0 +1,77 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"testing"
+)
+
+func TestMounts(t *testing.T) {
+	p := newPanel(t)
+
+	p.AddMount(&alligator.Mount{ID: 5, Name: "maps", Source: "/srv/maps", Target: "/maps", Servers: []*alligator.AppServer{{ID: 1}}})
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	m, err := app.CreateMount(alligator.MountDescriptor{Name: "assets", Source: "/srv/assets", Target: "/assets", ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != 6 || m.UUID == "" || !m.ReadOnly {
+		t.Errorf("unexpected mount: %+v", m)
+	}
+
+	var verr *alligator.ValidationError
+	if _, err = app.CreateMount(alligator.MountDescriptor{Name: "assets"}); !errors.As(err, &verr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	if m, err = app.AttachMountEggs(m.ID, []int{1}); err != nil {
+		t.Fatal(err)
+	}
+	if m, err = app.AttachMountNodes(m.ID, []int{1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.AttachMountNodes(m.ID, []int{42}); !errors.Is(err, alligator.ErrValidation) {
+		t.Errorf("expected ErrValidation for an unknown node, got %v", err)
+	}
+
+	m, err = app.GetMount(m.ID, options.GetMountOptions{Include: options.IncludeMounts{Eggs: true, Nodes: true, Servers: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Eggs) != 1 || m.Eggs[0].ID != 1 || len(m.Nodes) != 1 || m.Nodes[0].Name != "node-1" || len(m.Servers) != 0 {
+		t.Errorf("relationships were not decoded: %+v %+v %+v", m.Eggs, m.Nodes, m.Servers)
+	}
+
+	fields := m.UpdateDescriptor()
+	fields.ReadOnly = false
+	if m, err = app.UpdateMount(m.ID, *fields); err != nil {
+		t.Fatal(err)
+	}
+	if m.ReadOnly || m.Source != "/srv/assets" {
+		t.Errorf("unexpected update: %+v", m)
+	}
+
+	if err = app.DetachMountEgg(m.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = app.DetachMountNode(m.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	mounts, err := app.ListMounts(options.ListMountsOptions{Include: options.IncludeMounts{Eggs: true, Servers: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mounts) != 2 || len(mounts[0].Servers) != 1 || mounts[0].Servers[0].Name != "survival" || len(mounts[1].Eggs) != 0 {
+		t.Errorf("unexpected mounts: %+v", mounts)
+	}
+
+	if err = app.DeleteMount(m.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.GetMount(m.ID); !errors.Is(err, alligator.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
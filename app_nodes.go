@@ -2,10 +2,13 @@ package alligator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/m41denx/alligator/options"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -36,21 +39,22 @@ type Node struct {
 
 func (n *Node) UpdateDescriptor() *UpdateNodeDescriptor {
 	return &UpdateNodeDescriptor{
-		Name:               n.Name,
-		Description:        n.Description,
-		LocationID:         n.LocationID,
-		Public:             n.Public,
-		FQDN:               n.FQDN,
-		Scheme:             n.Scheme,
-		BehindProxy:        n.BehindProxy,
-		Memory:             n.Memory,
-		MemoryOverallocate: n.MemoryOverallocate,
-		Disk:               n.Disk,
-		DiskOverallocate:   n.DiskOverallocate,
-		DaemonBase:         n.DaemonBase,
-		DaemonSftp:         n.DaemonSftp,
-		DaemonListen:       n.DaemonListen,
-		UploadSize:         n.UploadSize,
+		Name:               Ptr(n.Name),
+		Description:        Ptr(n.Description),
+		LocationID:         Ptr(n.LocationID),
+		Public:             Ptr(n.Public),
+		FQDN:               Ptr(n.FQDN),
+		Scheme:             Ptr(n.Scheme),
+		BehindProxy:        Ptr(n.BehindProxy),
+		Memory:             Ptr(n.Memory),
+		MemoryOverallocate: Ptr(n.MemoryOverallocate),
+		Disk:               Ptr(n.Disk),
+		DiskOverallocate:   Ptr(n.DiskOverallocate),
+		DaemonBase:         Ptr(n.DaemonBase),
+		DaemonSftp:         Ptr(n.DaemonSftp),
+		DaemonListen:       Ptr(n.DaemonListen),
+		MaintenanceMode:    Ptr(n.MaintenanceMode),
+		UploadSize:         Ptr(n.UploadSize),
 	}
 }
 
@@ -88,19 +92,51 @@ func (r *ResponseNode) getNode() *Node {
 }
 
 func (a *Application) ListNodes(opts ...options.ListNodesOptions) ([]*Node, error) {
+	return a.ListNodesCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListNodesCtx(ctx context.Context, opts ...options.ListNodesOptions) ([]*Node, error) {
+	nodes, _, err := a.listNodes(ctx, opts...)
+	return nodes, err
+}
+
+func (a *Application) ListNodesPages(opts ...options.ListNodesOptions) *Pager[*Node] {
+	var o options.ListNodesOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Node, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listNodes(ctx, o)
+	})
+}
+
+func (a *Application) ListAllNodes(opts ...options.ListNodesOptions) ([]*Node, error) {
+	return a.ListAllNodesCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListAllNodesCtx(ctx context.Context, opts ...options.ListNodesOptions) ([]*Node, error) {
+	return a.ListNodesPages(opts...).All(ctx)
+}
+
+func (a *Application) listNodes(ctx context.Context, opts ...options.ListNodesOptions) ([]*Node, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
+		if err := options.ValidateRequestOptions(&opts[0]); err != nil {
+			return nil, nil, err
+		}
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/nodes?%s", o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nodes?%s", o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Holllllly shiii
@@ -108,9 +144,12 @@ func (a *Application) ListNodes(opts ...options.ListNodesOptions) ([]*Node, erro
 		Data []struct {
 			Attributes *ResponseNode `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	nodes := make([]*Node, 0, len(model.Data))
@@ -118,16 +157,20 @@ func (a *Application) ListNodes(opts ...options.ListNodesOptions) ([]*Node, erro
 		nodes = append(nodes, n.Attributes.getNode())
 	}
 
-	return nodes, nil
+	return nodes, model.Meta.Pagination, nil
 }
 
 func (a *Application) GetNode(id int, opts ...options.GetNodeOptions) (*Node, error) {
+	return a.GetNodeCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetNodeCtx(ctx context.Context, id int, opts ...options.GetNodeOptions) (*Node, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/nodes/%d?%s", id, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nodes/%d?%s", id, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +197,78 @@ type DeployableNodesDescriptor struct {
 	LocationsIDs []int `json:"location_ids,omitempty"`
 }
 
+func (d *DeployableNodesDescriptor) query() string {
+	vals := url.Values{}
+	if d.Page > 0 {
+		vals.Set("page", strconv.Itoa(d.Page))
+	}
+	vals.Set("memory", strconv.FormatInt(d.Memory, 10))
+	vals.Set("disk", strconv.FormatInt(d.Disk, 10))
+	for _, id := range d.LocationsIDs {
+		vals.Add("location_ids[]", strconv.Itoa(id))
+	}
+	return vals.Encode()
+}
+
+// ListDeployableNodes returns the public nodes with enough free memory and disk for a new server,
+// optionally limited to LocationsIDs.
+func (a *Application) ListDeployableNodes(fields DeployableNodesDescriptor) ([]*Node, error) {
+	return a.ListDeployableNodesCtx(context.Background(), fields)
+}
+
+func (a *Application) ListDeployableNodesCtx(ctx context.Context, fields DeployableNodesDescriptor) ([]*Node, error) {
+	nodes, _, err := a.listDeployableNodes(ctx, fields)
+	return nodes, err
+}
+
+func (a *Application) ListDeployableNodesPages(fields DeployableNodesDescriptor) *Pager[*Node] {
+	return newPager(fields.Page, func(ctx context.Context, page int) ([]*Node, *Pagination, error) {
+		fields := fields
+		fields.Page = page
+		return a.listDeployableNodes(ctx, fields)
+	})
+}
+
+func (a *Application) ListAllDeployableNodes(fields DeployableNodesDescriptor) ([]*Node, error) {
+	return a.ListAllDeployableNodesCtx(context.Background(), fields)
+}
+
+func (a *Application) ListAllDeployableNodesCtx(ctx context.Context, fields DeployableNodesDescriptor) ([]*Node, error) {
+	return a.ListDeployableNodesPages(fields).All(ctx)
+}
+
+func (a *Application) listDeployableNodes(ctx context.Context, fields DeployableNodesDescriptor) ([]*Node, *Pagination, error) {
+	req := a.newRequest(ctx, "GET", "/nodes/deployable?"+fields.query(), nil)
+	res, err := a.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *ResponseNode `json:"attributes"`
+		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make([]*Node, 0, len(model.Data))
+	for _, n := range model.Data {
+		nodes = append(nodes, n.Attributes.getNode())
+	}
+
+	return nodes, model.Meta.Pagination, nil
+}
+
 type NodeConfiguration struct {
 	Debug   bool   `json:"debug"`
 	UUID    string `json:"uuid"`
@@ -180,8 +295,12 @@ type NodeConfiguration struct {
 }
 
 func (a *Application) GetNodeConfiguration(id int) (*NodeConfiguration, error) {
-	req := a.newRequest("GET", fmt.Sprintf("/nodes/%d/configuration", id), nil)
-	res, err := a.Http.Do(req)
+	return a.GetNodeConfigurationCtx(context.Background(), id)
+}
+
+func (a *Application) GetNodeConfigurationCtx(ctx context.Context, id int) (*NodeConfiguration, error) {
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nodes/%d/configuration", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -218,12 +337,16 @@ type CreateNodeDescriptor struct {
 }
 
 func (a *Application) CreateNode(fields CreateNodeDescriptor) (*Node, error) {
+	return a.CreateNodeCtx(context.Background(), fields)
+}
+
+func (a *Application) CreateNodeCtx(ctx context.Context, fields CreateNodeDescriptor) (*Node, error) {
 	data, _ := json.Marshal(fields)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("POST", "/nodes", &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "POST", "/nodes", &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -243,25 +366,31 @@ func (a *Application) CreateNode(fields CreateNodeDescriptor) (*Node, error) {
 	return &model.Attributes, nil
 }
 
+// UpdateNodeDescriptor only sends the fields that are set, use Ptr to set them.
 type UpdateNodeDescriptor struct {
-	Name               string `json:"name"`
-	Description        string `json:"description"`
-	LocationID         int    `json:"location_id"`
-	Public             bool   `json:"public"`
-	FQDN               string `json:"fqdn"`
-	Scheme             string `json:"scheme"`
-	BehindProxy        bool   `json:"behind_proxy"`
-	Memory             int64  `json:"memory"`
-	MemoryOverallocate int64  `json:"memory_overallocate"`
-	Disk               int64  `json:"disk"`
-	DiskOverallocate   int64  `json:"disk_overallocate"`
-	DaemonBase         string `json:"daemon_base"`
-	DaemonSftp         int32  `json:"daemon_sftp"`
-	DaemonListen       int32  `json:"daemon_listen"`
-	UploadSize         int64  `json:"upload_size"`
+	Name               *string `json:"name,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	LocationID         *int    `json:"location_id,omitempty"`
+	Public             *bool   `json:"public,omitempty"`
+	FQDN               *string `json:"fqdn,omitempty"`
+	Scheme             *string `json:"scheme,omitempty"`
+	BehindProxy        *bool   `json:"behind_proxy,omitempty"`
+	Memory             *int64  `json:"memory,omitempty"`
+	MemoryOverallocate *int64  `json:"memory_overallocate,omitempty"`
+	Disk               *int64  `json:"disk,omitempty"`
+	DiskOverallocate   *int64  `json:"disk_overallocate,omitempty"`
+	DaemonBase         *string `json:"daemon_base,omitempty"`
+	DaemonSftp         *int32  `json:"daemon_sftp,omitempty"`
+	DaemonListen       *int32  `json:"daemon_listen,omitempty"`
+	MaintenanceMode    *bool   `json:"maintenance_mode,omitempty"`
+	UploadSize         *int64  `json:"upload_size,omitempty"`
 }
 
 func (a *Application) UpdateNode(id int, fields UpdateNodeDescriptor) (*Node, error) {
+	return a.UpdateNodeCtx(context.Background(), id, fields)
+}
+
+func (a *Application) UpdateNodeCtx(ctx context.Context, id int, fields UpdateNodeDescriptor) (*Node, error) {
 	data, _ := json.Marshal(fields)
 	if len(data) == 2 {
 		return nil, errors.New("no update fields specified")
@@ -270,8 +399,8 @@ func (a *Application) UpdateNode(id int, fields UpdateNodeDescriptor) (*Node, er
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("PATCH", fmt.Sprintf("/nodes/%d", id), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/nodes/%d", id), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -292,8 +421,12 @@ func (a *Application) UpdateNode(id int, fields UpdateNodeDescriptor) (*Node, er
 }
 
 func (a *Application) DeleteNode(id int) error {
-	req := a.newRequest("DELETE", fmt.Sprintf("/nodes/%d", id), nil)
-	res, err := a.Http.Do(req)
+	return a.DeleteNodeCtx(context.Background(), id)
+}
+
+func (a *Application) DeleteNodeCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/nodes/%d", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
@@ -333,28 +466,60 @@ func (r *ResponseAllocation) getAllocation() *Allocation {
 }
 
 func (a *Application) ListNodeAllocations(node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error) {
+	return a.ListNodeAllocationsCtx(context.Background(), node, opts...)
+}
+
+func (a *Application) ListNodeAllocationsCtx(ctx context.Context, node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error) {
+	allocs, _, err := a.listNodeAllocations(ctx, node, opts...)
+	return allocs, err
+}
+
+func (a *Application) ListNodeAllocationsPages(node int, opts ...options.ListNodeAllocationsOptions) *Pager[*Allocation] {
+	var o options.ListNodeAllocationsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Allocation, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listNodeAllocations(ctx, node, o)
+	})
+}
+
+func (a *Application) ListAllNodeAllocations(node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error) {
+	return a.ListAllNodeAllocationsCtx(context.Background(), node, opts...)
+}
+
+func (a *Application) ListAllNodeAllocationsCtx(ctx context.Context, node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, error) {
+	return a.ListNodeAllocationsPages(node, opts...).All(ctx)
+}
+
+func (a *Application) listNodeAllocations(ctx context.Context, node int, opts ...options.ListNodeAllocationsOptions) ([]*Allocation, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/nodes/%d/allocations?%s", node, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/nodes/%d/allocations?%s", node, o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var model struct {
 		Data []struct {
 			Attributes *ResponseAllocation `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	allocs := make([]*Allocation, 0, len(model.Data))
@@ -362,7 +527,7 @@ func (a *Application) ListNodeAllocations(node int, opts ...options.ListNodeAllo
 		allocs = append(allocs, alloc.Attributes.getAllocation())
 	}
 
-	return allocs, nil
+	return allocs, model.Meta.Pagination, nil
 }
 
 type CreateAllocationsDescriptor struct {
@@ -372,12 +537,16 @@ type CreateAllocationsDescriptor struct {
 }
 
 func (a *Application) CreateNodeAllocations(node int, fields CreateAllocationsDescriptor) error {
+	return a.CreateNodeAllocationsCtx(context.Background(), node, fields)
+}
+
+func (a *Application) CreateNodeAllocationsCtx(ctx context.Context, node int, fields CreateAllocationsDescriptor) error {
 	data, _ := json.Marshal(fields)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("POST", fmt.Sprintf("/nodes/%d/allocations", node), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/nodes/%d/allocations", node), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
@@ -387,8 +556,12 @@ func (a *Application) CreateNodeAllocations(node int, fields CreateAllocationsDe
 }
 
 func (a *Application) DeleteNodeAllocation(node, id int) error {
-	req := a.newRequest("DELETE", fmt.Sprintf("/nodes/%d/allocations/%d", node, id), nil)
-	res, err := a.Http.Do(req)
+	return a.DeleteNodeAllocationCtx(context.Background(), node, id)
+}
+
+func (a *Application) DeleteNodeAllocationCtx(ctx context.Context, node, id int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/nodes/%d/allocations/%d", node, id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
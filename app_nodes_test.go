@@ -0,0 +1,69 @@
+package alligator_test
+
+import (
+	"github.com/m41denx/alligator"
+	"testing"
+)
+
+func TestDeployableNodes(t *testing.T) {
+	p := newPanel(t)
+
+	p.AddLocation(&alligator.Location{ID: 2, Short: "us"})
+	p.AddNode(&alligator.Node{ID: 2, Name: "full", LocationID: 1, Public: true, Memory: 1024, Disk: 10240})
+	p.AddNode(&alligator.Node{ID: 3, Name: "free", LocationID: 1, Public: true, Memory: 8192, Disk: 10240})
+	p.AddNode(&alligator.Node{ID: 4, Name: "overseas", LocationID: 2, Public: true, Memory: 8192, Disk: 10240})
+	p.AddAllocation(3, &alligator.Allocation{ID: 10, IP: "10.0.0.3", Port: 25565})
+	p.AddServer(&alligator.AppServer{Name: "big", UserID: 1, NodeID: 2, Limits: alligator.Limits{Memory: 1024}})
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	nodes, err := app.ListDeployableNodes(alligator.DeployableNodesDescriptor{Memory: 2048, Disk: 1024, LocationsIDs: []int{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "free" {
+		t.Fatalf("expected only the free node, got %+v", nodes)
+	}
+
+	allocs, err := app.ListNodeAllocations(nodes[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := app.CreateServer(alligator.CreateServerDescriptor{
+		Name:       "deployed",
+		User:       1,
+		Egg:        1,
+		Limits:     &alligator.Limits{Memory: 2048, Disk: 1024},
+		Allocation: &alligator.AllocationDescriptor{Default: allocs[0].ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.NodeID != 3 {
+		t.Errorf("expected the server on node 3, got %d", srv.NodeID)
+	}
+}
+
+func TestUpdateNode(t *testing.T) {
+	p := newPanel(t)
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	if _, err := app.UpdateNode(1, alligator.UpdateNodeDescriptor{}); err == nil {
+		t.Error("expected an empty update to be refused")
+	}
+	node, err := app.UpdateNode(1, alligator.UpdateNodeDescriptor{MaintenanceMode: alligator.Ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !node.MaintenanceMode || node.Name != "node-1" || node.FQDN != "node1.example.com" {
+		t.Errorf("expected only maintenance_mode to change, got %+v", node)
+	}
+
+	fields := node.UpdateDescriptor()
+	fields.MaintenanceMode = alligator.Ptr(false)
+	if node, err = app.UpdateNode(1, *fields); err != nil {
+		t.Fatal(err)
+	}
+	if node.MaintenanceMode || node.Name != "node-1" {
+		t.Errorf("update descriptor didn't round-trip: %+v", node)
+	}
+}
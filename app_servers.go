@@ -2,6 +2,7 @@ package alligator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,48 +32,50 @@ type AppServer struct {
 		Installed      int                    `json:"installed"`
 		Environment    map[string]interface{} `json:"environment"`
 	} `json:"container"`
-	CreatedAt   *time.Time     `json:"created_at"`
-	UpdatedAt   *time.Time     `json:"updated_at,omitempty"`
-	Allocations []*Allocation  `json:"-"`
-	UserObject  *User          `json:"-"`
-	Subusers    []*User        `json:"-"`
-	Location    *Location      `json:"-"`
-	NodeObject  *Node          `json:"-"`
-	NestObject  *Nest          `json:"-"`
-	EggObject   *Egg           `json:"-"`
-	Variables   []*EggVariable `json:"-"`
+	CreatedAt   *time.Time        `json:"created_at"`
+	UpdatedAt   *time.Time        `json:"updated_at,omitempty"`
+	Allocations []*Allocation     `json:"-"`
+	UserObject  *User             `json:"-"`
+	Subusers    []*User           `json:"-"`
+	Location    *Location         `json:"-"`
+	NodeObject  *Node             `json:"-"`
+	NestObject  *Nest             `json:"-"`
+	EggObject   *Egg              `json:"-"`
+	Variables   []*ServerVariable `json:"-"`
+	Databases   []*AppDatabase    `json:"-"`
 }
 
 func (s *AppServer) BuildDescriptor() *ServerBuildDescriptor {
+	limits, features := s.Limits, s.FeatureLimits
 	return &ServerBuildDescriptor{
 		Allocation:        s.Allocation,
-		OOMDisabled:       s.Limits.OOMDisabled,
-		Limits:            s.Limits,
+		OOMDisabled:       Ptr(s.Limits.OOMDisabled),
+		Limits:            &limits,
 		AddAllocations:    []int{},
 		RemoveAllocations: []int{},
-		FeatureLimits:     s.FeatureLimits,
+		FeatureLimits:     &features,
 	}
 }
 
 func (s *AppServer) DetailsDescriptor() *ServerDetailsDescriptor {
 	return &ServerDetailsDescriptor{
-		ExternalID:  s.ExternalID,
-		Name:        s.Name,
-		User:        s.UserID,
-		Description: s.Description,
+		ExternalID:  Ptr(s.ExternalID),
+		Name:        Ptr(s.Name),
+		User:        Ptr(s.UserID),
+		Description: Ptr(s.Description),
 	}
 }
 
 func (s *AppServer) StartupDescriptor() *ServerStartupDescriptor {
 	return &ServerStartupDescriptor{
-		Startup:     s.Container.StartupCommand,
+		Startup:     Ptr(s.Container.StartupCommand),
 		Environment: s.Container.Environment,
-		Egg:         s.EggID,
-		Image:       s.Container.Image,
+		Egg:         Ptr(s.EggID),
+		Image:       Ptr(s.Container.Image),
+		SkipScripts: Ptr(false),
 	}
 }
 
-// TODO: databases
 type ResponseServer struct {
 	*AppServer
 	Relationships struct {
@@ -103,9 +106,14 @@ type ResponseServer struct {
 		} `json:"egg"`
 		Variables struct {
 			Data []struct {
-				Attributes *EggVariable `json:"attributes"`
+				Attributes *ServerVariable `json:"attributes"`
 			} `json:"data"`
 		} `json:"variables"`
+		Databases struct {
+			Data []struct {
+				Attributes *ResponseDatabase `json:"attributes"`
+			} `json:"data"`
+		} `json:"databases"`
 	} `json:"relationships"`
 }
 
@@ -124,36 +132,75 @@ func (r *ResponseServer) getServer() *AppServer {
 	server.NodeObject = r.Relationships.Node.Attributes
 	server.NestObject = r.Relationships.Nest.Attributes
 	server.EggObject = r.Relationships.Egg.Attributes
-	server.Variables = make([]*EggVariable, 0)
+	server.Variables = make([]*ServerVariable, 0)
 	for _, v := range r.Relationships.Variables.Data {
 		server.Variables = append(server.Variables, v.Attributes)
 	}
+	server.Databases = make([]*AppDatabase, 0)
+	for _, d := range r.Relationships.Databases.Data {
+		server.Databases = append(server.Databases, d.Attributes.getDatabase())
+	}
 	return server
 }
 
 func (a *Application) ListServers(opts ...options.ListServersOptions) ([]*AppServer, error) {
+	return a.ListServersCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListServersCtx(ctx context.Context, opts ...options.ListServersOptions) ([]*AppServer, error) {
+	servers, _, err := a.listServers(ctx, opts...)
+	return servers, err
+}
+
+func (a *Application) ListServersPages(opts ...options.ListServersOptions) *Pager[*AppServer] {
+	var o options.ListServersOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*AppServer, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listServers(ctx, o)
+	})
+}
+
+func (a *Application) ListAllServers(opts ...options.ListServersOptions) ([]*AppServer, error) {
+	return a.ListAllServersCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListAllServersCtx(ctx context.Context, opts ...options.ListServersOptions) ([]*AppServer, error) {
+	return a.ListServersPages(opts...).All(ctx)
+}
+
+func (a *Application) listServers(ctx context.Context, opts ...options.ListServersOptions) ([]*AppServer, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
+		if err := options.ValidateRequestOptions(&opts[0]); err != nil {
+			return nil, nil, err
+		}
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/servers?%s", o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/servers?%s", o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var model struct {
 		Data []struct {
 			Attributes *ResponseServer `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	servers := make([]*AppServer, 0, len(model.Data))
@@ -161,16 +208,20 @@ func (a *Application) ListServers(opts ...options.ListServersOptions) ([]*AppSer
 		servers = append(servers, s.Attributes.getServer())
 	}
 
-	return servers, nil
+	return servers, model.Meta.Pagination, nil
 }
 
 func (a *Application) GetServer(id int, opts ...options.GetServerOptions) (*AppServer, error) {
+	return a.GetServerCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetServerCtx(ctx context.Context, id int, opts ...options.GetServerOptions) (*AppServer, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/servers/%d?%s", id, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/servers/%d?%s", id, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -191,12 +242,16 @@ func (a *Application) GetServer(id int, opts ...options.GetServerOptions) (*AppS
 }
 
 func (a *Application) GetServerExternal(id string, opts ...options.GetServerOptions) (*AppServer, error) {
+	return a.GetServerExternalCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetServerExternalCtx(ctx context.Context, id string, opts ...options.GetServerOptions) (*AppServer, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/servers/external/%s?%s", id, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/servers/external/%s?%s", id, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -246,6 +301,10 @@ type CreateServerDescriptor struct {
 }
 
 func (a *Application) CreateServer(fields CreateServerDescriptor) (*AppServer, error) {
+	return a.CreateServerCtx(context.Background(), fields)
+}
+
+func (a *Application) CreateServerCtx(ctx context.Context, fields CreateServerDescriptor) (*AppServer, error) {
 	if fields.Allocation == nil && fields.Deploy == nil {
 		return nil, errors.New("the allocation object or deploy object must be specified")
 	}
@@ -254,8 +313,8 @@ func (a *Application) CreateServer(fields CreateServerDescriptor) (*AppServer, e
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("POST", "/servers", &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "POST", "/servers", &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -275,16 +334,21 @@ func (a *Application) CreateServer(fields CreateServerDescriptor) (*AppServer, e
 	return &model.Attributes, nil
 }
 
+// ServerBuildDescriptor only sends the fields that are set, use Ptr to set OOMDisabled.
 type ServerBuildDescriptor struct {
-	Allocation        int           `json:"allocation,omitempty"`
-	OOMDisabled       bool          `json:"oom_disabled,omitempty"`
-	Limits            Limits        `json:"limits,omitempty"`
-	AddAllocations    []int         `json:"add_allocations,omitempty"`
-	RemoveAllocations []int         `json:"remove_allocations,omitempty"`
-	FeatureLimits     FeatureLimits `json:"feature_limits,omitempty"`
+	Allocation        int            `json:"allocation,omitempty"`
+	OOMDisabled       *bool          `json:"oom_disabled,omitempty"`
+	Limits            *Limits        `json:"limits,omitempty"`
+	AddAllocations    []int          `json:"add_allocations,omitempty"`
+	RemoveAllocations []int          `json:"remove_allocations,omitempty"`
+	FeatureLimits     *FeatureLimits `json:"feature_limits,omitempty"`
 }
 
 func (a *Application) UpdateServerBuild(id int, fields ServerBuildDescriptor) (*AppServer, error) {
+	return a.UpdateServerBuildCtx(context.Background(), id, fields)
+}
+
+func (a *Application) UpdateServerBuildCtx(ctx context.Context, id int, fields ServerBuildDescriptor) (*AppServer, error) {
 	data, _ := json.Marshal(fields)
 	if len(data) == 2 {
 		return nil, errors.New("no build fields specified")
@@ -293,8 +357,8 @@ func (a *Application) UpdateServerBuild(id int, fields ServerBuildDescriptor) (*
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("PATCH", fmt.Sprintf("/servers/%d/build", id), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/servers/%d/build", id), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -314,14 +378,19 @@ func (a *Application) UpdateServerBuild(id int, fields ServerBuildDescriptor) (*
 	return &model.Attributes, nil
 }
 
+// ServerDetailsDescriptor only sends the fields that are set, use Ptr to set them.
 type ServerDetailsDescriptor struct {
-	ExternalID  string `json:"external_id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	User        int    `json:"user,omitempty"`
-	Description string `json:"description,omitempty"`
+	ExternalID  *string `json:"external_id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	User        *int    `json:"user,omitempty"`
+	Description *string `json:"description,omitempty"`
 }
 
 func (a *Application) UpdateServerDetails(id int, fields ServerDetailsDescriptor) (*AppServer, error) {
+	return a.UpdateServerDetailsCtx(context.Background(), id, fields)
+}
+
+func (a *Application) UpdateServerDetailsCtx(ctx context.Context, id int, fields ServerDetailsDescriptor) (*AppServer, error) {
 	data, _ := json.Marshal(fields)
 	if len(data) == 2 {
 		return nil, errors.New("no details fields specified")
@@ -330,8 +399,8 @@ func (a *Application) UpdateServerDetails(id int, fields ServerDetailsDescriptor
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("PATCH", fmt.Sprintf("/servers/%d/details", id), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/servers/%d/details", id), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -351,15 +420,20 @@ func (a *Application) UpdateServerDetails(id int, fields ServerDetailsDescriptor
 	return &model.Attributes, nil
 }
 
+// ServerStartupDescriptor only sends the fields that are set, use Ptr to set them.
 type ServerStartupDescriptor struct {
-	Startup     string                 `json:"startup"`
-	Environment map[string]interface{} `json:"environment"`
-	Egg         int                    `json:"egg,omitempty"`
-	Image       string                 `json:"image"`
-	SkipScripts bool                   `json:"skip_scripts"`
+	Startup     *string                `json:"startup,omitempty"`
+	Environment map[string]interface{} `json:"environment,omitempty"`
+	Egg         *int                   `json:"egg,omitempty"`
+	Image       *string                `json:"image,omitempty"`
+	SkipScripts *bool                  `json:"skip_scripts,omitempty"`
 }
 
 func (a *Application) UpdateServerStartup(id int, fields ServerStartupDescriptor) (*AppServer, error) {
+	return a.UpdateServerStartupCtx(context.Background(), id, fields)
+}
+
+func (a *Application) UpdateServerStartupCtx(ctx context.Context, id int, fields ServerStartupDescriptor) (*AppServer, error) {
 	data, _ := json.Marshal(fields)
 	if len(data) == 2 {
 		return nil, errors.New("no startup fields specified")
@@ -368,8 +442,8 @@ func (a *Application) UpdateServerStartup(id int, fields ServerStartupDescriptor
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("PATCH", fmt.Sprintf("/servers/%d/startup", id), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/servers/%d/startup", id), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -390,8 +464,12 @@ func (a *Application) UpdateServerStartup(id int, fields ServerStartupDescriptor
 }
 
 func (a *Application) SuspendServer(id int) error {
-	req := a.newRequest("POST", fmt.Sprintf("/servers/%d/suspend", id), nil)
-	res, err := a.Http.Do(req)
+	return a.SuspendServerCtx(context.Background(), id)
+}
+
+func (a *Application) SuspendServerCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/servers/%d/suspend", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
@@ -401,8 +479,12 @@ func (a *Application) SuspendServer(id int) error {
 }
 
 func (a *Application) UnsuspendServer(id int) error {
-	req := a.newRequest("POST", fmt.Sprintf("/servers/%d/unsuspend", id), nil)
-	res, err := a.Http.Do(req)
+	return a.UnsuspendServerCtx(context.Background(), id)
+}
+
+func (a *Application) UnsuspendServerCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/servers/%d/unsuspend", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
@@ -412,8 +494,12 @@ func (a *Application) UnsuspendServer(id int) error {
 }
 
 func (a *Application) ReinstallServer(id int) error {
-	req := a.newRequest("POST", fmt.Sprintf("/servers/%d/reinstall", id), nil)
-	res, err := a.Http.Do(req)
+	return a.ReinstallServerCtx(context.Background(), id)
+}
+
+func (a *Application) ReinstallServerCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "POST", fmt.Sprintf("/servers/%d/reinstall", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
@@ -423,13 +509,17 @@ func (a *Application) ReinstallServer(id int) error {
 }
 
 func (a *Application) DeleteServer(id int, force bool) error {
+	return a.DeleteServerCtx(context.Background(), id, force)
+}
+
+func (a *Application) DeleteServerCtx(ctx context.Context, id int, force bool) error {
 	url := fmt.Sprintf("/servers/%d", id)
 	if force {
 		url += "/force"
 	}
 
-	req := a.newRequest("DELETE", url, nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "DELETE", url, nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
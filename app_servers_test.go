@@ -0,0 +1,209 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"net/http"
+	"testing"
+)
+
+func TestServerRelationships(t *testing.T) {
+	p := newPanel(t)
+
+	p.AddAllocation(1, &alligator.Allocation{ID: 3, IP: "10.0.0.2", Alias: "play.example.com", Port: 25565})
+	srv := &alligator.AppServer{ID: 2, Name: "creative", UserID: 1, Allocation: 3, NestID: 1, EggID: 1}
+	srv.Container.Environment = map[string]interface{}{"SERVER_JARFILE": "paper.jar"}
+	p.AddServer(srv)
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	got, err := app.GetServer(2, options.GetServerOptions{Include: options.IncludeServers{Variables: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Variables) != 1 || got.Variables[0].DefaultValue != "server.jar" || got.Variables[0].ServerValue != "paper.jar" {
+		t.Errorf("expected the egg default and the server value, got %+v", got.Variables)
+	}
+
+	allocs, err := app.ListNodeAllocations(1, options.ListNodeAllocationsOptions{
+		Include: options.IncludeAllocations{Server: true},
+		Filters: options.FiltersAllocations{IP: "10.0.0.1", Port: 25565},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocs) != 1 || allocs[0].Server == nil || allocs[0].Server.Name != "survival" {
+		t.Errorf("expected the allocation bound to survival, got %+v", allocs)
+	}
+
+	server, unassigned := 2, 0
+	for filters, want := range map[options.FiltersAllocations]int{
+		{IPAlias: "play"}:       3,
+		{ServerID: &server}:     3,
+		{ServerID: &unassigned}: 2,
+	} {
+		allocs, err = app.ListNodeAllocations(1, options.ListNodeAllocationsOptions{Filters: filters})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(allocs) != 1 || allocs[0].ID != want {
+			t.Errorf("%+v: expected allocation %d, got %+v", filters, want, allocs)
+		}
+	}
+}
+
+func TestFiltersAndSorts(t *testing.T) {
+	p := newPanel(t)
+
+	p.AddNode(&alligator.Node{ID: 2, Name: "node-2", LocationID: 1, FQDN: "node2.example.com", Memory: 4096})
+	p.AddNode(&alligator.Node{ID: 3, Name: "node-3", LocationID: 1, FQDN: "node3.example.com", Memory: 8192})
+	srv := &alligator.AppServer{ID: 2, Name: "proxy", UserID: 1, NodeID: 2}
+	srv.Container.Image = "ghcr.io/pterodactyl/yolks:java_21"
+	p.AddServer(srv)
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	sent := 0
+	app.Use(func(next alligator.Doer) alligator.Doer {
+		return func(req *http.Request) (*http.Response, error) {
+			sent++
+			return next(req)
+		}
+	})
+
+	servers, err := app.ListServers(options.ListServersOptions{Filters: options.FiltersServers{Image: "java_21"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 1 || servers[0].Name != "proxy" {
+		t.Errorf("expected only proxy, got %+v", servers)
+	}
+
+	nodes, err := app.ListNodes(options.ListNodesOptions{
+		Filters: options.FiltersNodes{FQDN: "example.com"},
+		SortBy:  options.ListNodesSort_Memory_DESC,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 3 || nodes[0].ID != 3 || nodes[1].ID != 2 {
+		t.Errorf("expected nodes sorted by memory, got %+v", nodes)
+	}
+
+	locations, err := app.ListLocations(options.ListLocationsOptions{Filters: options.FiltersLocations{Long: "europe"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 1 || locations[0].Short != "eu" {
+		t.Errorf("expected eu, got %+v", locations)
+	}
+
+	sent = 0
+	if _, err = app.ListServers(options.ListServersOptions{SortBy: "name"}); !errors.Is(err, options.ErrUnknownSort) {
+		t.Errorf("expected ErrUnknownSort, got %v", err)
+	}
+	if sent != 0 {
+		t.Errorf("the request was sent with an unknown sort")
+	}
+}
+
+func TestOptionalFields(t *testing.T) {
+	p := newPanel(t)
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	admin, err := app.GetUser(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	same, err := app.UpdateUser(admin.ID, *admin.UpdateDescriptor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.Username != admin.Username || same.Email != admin.Email || !same.RootAdmin {
+		t.Errorf("update descriptor didn't round-trip: %+v", same)
+	}
+
+	demoted, err := app.UpdateUser(admin.ID, alligator.UpdateUserDescriptor{RootAdmin: alligator.Ptr(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if demoted.RootAdmin || demoted.Username != "admin" {
+		t.Errorf("expected only root_admin to change, got %+v", demoted)
+	}
+
+	srv, err := app.UpdateServerBuild(1, alligator.ServerBuildDescriptor{Allocation: 1, OOMDisabled: alligator.Ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !srv.Limits.OOMDisabled || srv.FeatureLimits.Databases != 1 {
+		t.Errorf("expected only oom_disabled to change, got %+v", srv)
+	}
+
+	build := srv.BuildDescriptor()
+	build.OOMDisabled = alligator.Ptr(false)
+	build.FeatureLimits.Databases = 0
+	if srv, err = app.UpdateServerBuild(1, *build); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Limits.OOMDisabled || srv.FeatureLimits.Databases != 0 {
+		t.Errorf("expected the OOM killer enabled and no databases, got %+v", srv)
+	}
+
+	details := srv.DetailsDescriptor()
+	details.Description = alligator.Ptr("")
+	if srv, err = app.UpdateServerDetails(1, *details); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Name != "survival" || srv.UserID != 1 {
+		t.Errorf("details descriptor didn't round-trip: %+v", srv)
+	}
+}
+
+func TestEnvironmentRules(t *testing.T) {
+	p := newPanel(t)
+
+	egg := p.AddEgg(&alligator.Egg{NestID: 1, Name: "Vanilla", Variables: []*alligator.EggVariable{
+		{Name: "Server Jar File", EnvVariable: "SERVER_JARFILE", Rules: `required|regex:/^([\w\d._-]+)(\.jar)$/`},
+		{Name: "Version", EnvVariable: "VERSION", Rules: "nullable|string|max:20"},
+	}})
+	env := map[string]interface{}{"SERVER_JARFILE": "server.zip", "VERSION": "1.20.4-experimental-snapshot"}
+	want := map[string]alligator.FieldError{
+		"SERVER_JARFILE": {Rule: "regex", Detail: "The Server Jar File variable format is invalid."},
+		"VERSION":        {Rule: "max", Detail: "The Version variable may not be greater than 20 characters."},
+	}
+
+	var eerr *alligator.EnvironmentError
+	if err := egg.ValidateEnvironment(env); !errors.As(err, &eerr) {
+		t.Fatalf("expected the environment to be rejected locally, got %v", err)
+	}
+	for env, fe := range want {
+		if got := eerr.Fields[env]; len(eerr.Fields) != len(want) || len(got) != 1 || got[0] != fe {
+			t.Errorf("%s: expected %v locally, got %v", env, fe, eerr.Fields)
+		}
+	}
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	_, err := app.CreateServer(alligator.CreateServerDescriptor{
+		Name:        "vanilla",
+		User:        1,
+		Egg:         egg.ID,
+		Environment: env,
+		Allocation:  &alligator.AllocationDescriptor{Default: 2},
+	})
+	var verr *alligator.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	for env, fe := range want {
+		if got := verr.Fields["environment."+env]; len(verr.Fields) != len(want) || len(got) != 1 || got[0] != fe {
+			t.Errorf("%s: expected %v from the panel, got %v", env, fe, verr.Fields)
+		}
+	}
+
+	env["SERVER_JARFILE"], env["VERSION"] = "server.jar", nil
+	if err = egg.ValidateEnvironment(env); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = app.UpdateServerStartup(1, alligator.ServerStartupDescriptor{Egg: alligator.Ptr(egg.ID), Environment: env}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,6 +2,7 @@ package alligator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/m41denx/alligator/options"
@@ -30,30 +31,62 @@ func (u *User) FullName() string {
 
 func (u *User) UpdateDescriptor() *UpdateUserDescriptor {
 	return &UpdateUserDescriptor{
-		ExternalID: u.ExternalID,
-		Email:      u.Email,
-		Username:   u.Username,
-		FirstName:  u.FirstName,
-		LastName:   u.LastName,
-		Language:   u.Language,
-		RootAdmin:  u.RootAdmin,
+		ExternalID: Ptr(u.ExternalID),
+		Email:      Ptr(u.Email),
+		Username:   Ptr(u.Username),
+		FirstName:  Ptr(u.FirstName),
+		LastName:   Ptr(u.LastName),
+		Language:   Ptr(u.Language),
+		RootAdmin:  Ptr(u.RootAdmin),
 	}
 }
 
 func (a *Application) ListUsers(opts ...options.ListUsersOptions) ([]*User, error) {
+	return a.ListUsersCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListUsersCtx(ctx context.Context, opts ...options.ListUsersOptions) ([]*User, error) {
+	users, _, err := a.listUsers(ctx, opts...)
+	return users, err
+}
+
+func (a *Application) ListUsersPages(opts ...options.ListUsersOptions) *Pager[*User] {
+	var o options.ListUsersOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*User, *Pagination, error) {
+		o := o
+		o.Page = page
+		return a.listUsers(ctx, o)
+	})
+}
+
+func (a *Application) ListAllUsers(opts ...options.ListUsersOptions) ([]*User, error) {
+	return a.ListAllUsersCtx(context.Background(), opts...)
+}
+
+func (a *Application) ListAllUsersCtx(ctx context.Context, opts ...options.ListUsersOptions) ([]*User, error) {
+	return a.ListUsersPages(opts...).All(ctx)
+}
+
+func (a *Application) listUsers(ctx context.Context, opts ...options.ListUsersOptions) ([]*User, *Pagination, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
+		if err := options.ValidateRequestOptions(&opts[0]); err != nil {
+			return nil, nil, err
+		}
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/users?%s", o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/users?%s", o), nil)
+	res, err := a.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	buf, err := validate(res)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var model struct {
@@ -69,9 +102,12 @@ func (a *Application) ListUsers(opts ...options.ListUsersOptions) ([]*User, erro
 				} `json:"relationships"`
 			} `json:"attributes"`
 		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	users := make([]*User, 0)
@@ -84,16 +120,20 @@ func (a *Application) ListUsers(opts ...options.ListUsersOptions) ([]*User, erro
 		users = append(users, user)
 	}
 
-	return users, nil
+	return users, model.Meta.Pagination, nil
 }
 
 func (a *Application) GetUser(id int, opts ...options.GetUserOptions) (*User, error) {
+	return a.GetUserCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetUserCtx(ctx context.Context, id int, opts ...options.GetUserOptions) (*User, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/users/%d?%s", id, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/users/%d?%s", id, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -129,12 +169,16 @@ func (a *Application) GetUser(id int, opts ...options.GetUserOptions) (*User, er
 }
 
 func (a *Application) GetUserExternal(id string, opts ...options.GetUserOptions) (*User, error) {
+	return a.GetUserExternalCtx(context.Background(), id, opts...)
+}
+
+func (a *Application) GetUserExternalCtx(ctx context.Context, id string, opts ...options.GetUserOptions) (*User, error) {
 	var o string
 	if opts != nil && len(opts) > 0 {
 		o = options.ParseRequestOptions(&opts[0])
 	}
-	req := a.newRequest("GET", fmt.Sprintf("/users/external/%s?%s", id, o), nil)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "GET", fmt.Sprintf("/users/external/%s?%s", id, o), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -180,12 +224,16 @@ type CreateUserDescriptor struct {
 }
 
 func (a *Application) CreateUser(fields CreateUserDescriptor) (*User, error) {
+	return a.CreateUserCtx(context.Background(), fields)
+}
+
+func (a *Application) CreateUserCtx(ctx context.Context, fields CreateUserDescriptor) (*User, error) {
 	data, _ := json.Marshal(fields)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("POST", "/users", &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "POST", "/users", &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -205,24 +253,29 @@ func (a *Application) CreateUser(fields CreateUserDescriptor) (*User, error) {
 	return &model.Attributes, nil
 }
 
+// UpdateUserDescriptor only sends the fields that are set, use Ptr to set them.
 type UpdateUserDescriptor struct {
-	ExternalID string `json:"external_id,omitempty"`
-	Email      string `json:"email,omitempty"`
-	Username   string `json:"username,omitempty"`
-	Password   string `json:"password,omitempty"`
-	FirstName  string `json:"first_name,omitempty"`
-	LastName   string `json:"last_name,omitempty"`
-	Language   string `json:"language,omitempty"`
-	RootAdmin  bool   `json:"root_admin,omitempty"`
+	ExternalID *string `json:"external_id,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Username   *string `json:"username,omitempty"`
+	Password   *string `json:"password,omitempty"`
+	FirstName  *string `json:"first_name,omitempty"`
+	LastName   *string `json:"last_name,omitempty"`
+	Language   *string `json:"language,omitempty"`
+	RootAdmin  *bool   `json:"root_admin,omitempty"`
 }
 
 func (a *Application) UpdateUser(id int, fields UpdateUserDescriptor) (*User, error) {
+	return a.UpdateUserCtx(context.Background(), id, fields)
+}
+
+func (a *Application) UpdateUserCtx(ctx context.Context, id int, fields UpdateUserDescriptor) (*User, error) {
 	data, _ := json.Marshal(fields)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := a.newRequest("PATCH", fmt.Sprintf("/users/%d", id), &body)
-	res, err := a.Http.Do(req)
+	req := a.newRequest(ctx, "PATCH", fmt.Sprintf("/users/%d", id), &body)
+	res, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -243,8 +296,12 @@ func (a *Application) UpdateUser(id int, fields UpdateUserDescriptor) (*User, er
 }
 
 func (a *Application) DeleteUser(id int) error {
-	req := a.newRequest("DELETE", fmt.Sprintf("/users/%d", id), nil)
-	res, err := a.Http.Do(req)
+	return a.DeleteUserCtx(context.Background(), id)
+}
+
+func (a *Application) DeleteUserCtx(ctx context.Context, id int) error {
+	req := a.newRequest(ctx, "DELETE", fmt.Sprintf("/users/%d", id), nil)
+	res, err := a.do(req)
 	if err != nil {
 		return err
 	}
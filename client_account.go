@@ -2,6 +2,7 @@ package alligator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"time"
 )
@@ -21,8 +22,12 @@ func (a *Account) FullName() string {
 }
 
 func (c *Client) GetAccount() (*Account, error) {
-	req := c.newRequest("GET", "/account", nil)
-	res, err := c.Http.Do(req)
+	return c.GetAccountCtx(context.Background())
+}
+
+func (c *Client) GetAccountCtx(ctx context.Context) (*Account, error) {
+	req := c.newRequest(ctx, "GET", "/account", nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +53,12 @@ type TwoFactorData struct {
 }
 
 func (c *Client) GetTwoFactor() (*TwoFactorData, error) {
-	req := c.newRequest("GET", "/account/two-factor", nil)
-	res, err := c.Http.Do(req)
+	return c.GetTwoFactorCtx(context.Background())
+}
+
+func (c *Client) GetTwoFactorCtx(ctx context.Context) (*TwoFactorData, error) {
+	req := c.newRequest(ctx, "GET", "/account/two-factor", nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -70,12 +79,16 @@ func (c *Client) GetTwoFactor() (*TwoFactorData, error) {
 }
 
 func (c *Client) EnableTwoFactor(code int) ([]string, error) {
+	return c.EnableTwoFactorCtx(context.Background(), code)
+}
+
+func (c *Client) EnableTwoFactorCtx(ctx context.Context, code int) ([]string, error) {
 	data, _ := json.Marshal(map[string]int{"code": code})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", "/account/two-factor", &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", "/account/two-factor", &body)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -98,12 +111,16 @@ func (c *Client) EnableTwoFactor(code int) ([]string, error) {
 }
 
 func (c *Client) DisableTwoFactor(password string) error {
+	return c.DisableTwoFactorCtx(context.Background(), password)
+}
+
+func (c *Client) DisableTwoFactorCtx(ctx context.Context, password string) error {
 	data, _ := json.Marshal(map[string]string{"password": password})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("DELETE", "/account/two-factor", &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "DELETE", "/account/two-factor", &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -113,12 +130,16 @@ func (c *Client) DisableTwoFactor(password string) error {
 }
 
 func (c *Client) UpdateEmail(email, password string) error {
+	return c.UpdateEmailCtx(context.Background(), email, password)
+}
+
+func (c *Client) UpdateEmailCtx(ctx context.Context, email, password string) error {
 	data, _ := json.Marshal(map[string]string{"email": email, "password": password})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("PUT", "/account/email", &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "PUT", "/account/email", &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -128,6 +149,10 @@ func (c *Client) UpdateEmail(email, password string) error {
 }
 
 func (c *Client) UpdatePassword(old, new string) error {
+	return c.UpdatePasswordCtx(context.Background(), old, new)
+}
+
+func (c *Client) UpdatePasswordCtx(ctx context.Context, old, new string) error {
 	data, _ := json.Marshal(map[string]string{
 		"current_password":      old,
 		"password":              new,
@@ -136,8 +161,8 @@ func (c *Client) UpdatePassword(old, new string) error {
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("PUT", "/account/password", &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "PUT", "/account/password", &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -155,8 +180,12 @@ type ApiKey struct {
 }
 
 func (c *Client) GetApiKeys() ([]*ApiKey, error) {
-	req := c.newRequest("GET", "/account/api-keys", nil)
-	res, err := c.Http.Do(req)
+	return c.GetApiKeysCtx(context.Background())
+}
+
+func (c *Client) GetApiKeysCtx(ctx context.Context) ([]*ApiKey, error) {
+	req := c.newRequest(ctx, "GET", "/account/api-keys", nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +213,10 @@ func (c *Client) GetApiKeys() ([]*ApiKey, error) {
 }
 
 func (c *Client) CreateKey(description string, ips []string) (*ApiKey, error) {
+	return c.CreateKeyCtx(context.Background(), description, ips)
+}
+
+func (c *Client) CreateKeyCtx(ctx context.Context, description string, ips []string) (*ApiKey, error) {
 	data, _ := json.Marshal(map[string]interface{}{
 		"description": description,
 		"allowed_ips": ips,
@@ -191,8 +224,8 @@ func (c *Client) CreateKey(description string, ips []string) (*ApiKey, error) {
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", "/account/api-keys", &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", "/account/api-keys", &body)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -213,8 +246,12 @@ func (c *Client) CreateKey(description string, ips []string) (*ApiKey, error) {
 }
 
 func (c *Client) DeleteKey(identifier string) error {
-	req := c.newRequest("DELETE", "/account/api-keys/"+identifier, nil)
-	res, err := c.Http.Do(req)
+	return c.DeleteKeyCtx(context.Background(), identifier)
+}
+
+func (c *Client) DeleteKeyCtx(ctx context.Context, identifier string) error {
+	req := c.newRequest(ctx, "DELETE", "/account/api-keys/"+identifier, nil)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,253 @@
+package alligator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/m41denx/alligator/options"
+	"strings"
+	"time"
+)
+
+type Backup struct {
+	UUID         string     `json:"uuid"`
+	Name         string     `json:"name"`
+	IgnoredFiles []string   `json:"ignored_files"`
+	Checksum     string     `json:"checksum"` // e.g. "sha1:...", empty until the backup completes
+	Bytes        int64      `json:"bytes"`
+	IsSuccessful bool       `json:"is_successful"`
+	IsLocked     bool       `json:"is_locked"` // Locked backups can't be deleted
+	CreatedAt    *time.Time `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
+
+func (c *Client) ListBackups(identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error) {
+	return c.ListBackupsCtx(context.Background(), identifier, opts...)
+}
+
+func (c *Client) ListBackupsCtx(ctx context.Context, identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error) {
+	backups, _, err := c.listBackups(ctx, identifier, opts...)
+	return backups, err
+}
+
+func (c *Client) ListBackupsPages(identifier string, opts ...options.ListBackupsOptions) *Pager[*Backup] {
+	var o options.ListBackupsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return newPager(o.Page, func(ctx context.Context, page int) ([]*Backup, *Pagination, error) {
+		o := o
+		o.Page = page
+		return c.listBackups(ctx, identifier, o)
+	})
+}
+
+func (c *Client) ListAllBackups(identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error) {
+	return c.ListAllBackupsCtx(context.Background(), identifier, opts...)
+}
+
+func (c *Client) ListAllBackupsCtx(ctx context.Context, identifier string, opts ...options.ListBackupsOptions) ([]*Backup, error) {
+	return c.ListBackupsPages(identifier, opts...).All(ctx)
+}
+
+func (c *Client) listBackups(ctx context.Context, identifier string, opts ...options.ListBackupsOptions) ([]*Backup, *Pagination, error) {
+	var o string
+	if len(opts) > 0 {
+		o = options.ParseRequestOptions(&opts[0])
+	}
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/backups?%s", identifier, o), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *Backup `json:"attributes"`
+		} `json:"data"`
+		Meta struct {
+			Pagination *Pagination `json:"pagination"`
+		} `json:"meta"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, nil, err
+	}
+
+	backups := make([]*Backup, 0, len(model.Data))
+	for _, b := range model.Data {
+		backups = append(backups, b.Attributes)
+	}
+
+	return backups, model.Meta.Pagination, nil
+}
+
+type BackupDescriptor struct {
+	Name         string   // Defaults to a timestamp when empty
+	IgnoredFiles []string // Paths and patterns left out of the backup, like a .gitignore
+	Locked       bool
+}
+
+func (c *Client) CreateBackup(identifier string, fields BackupDescriptor) (*Backup, error) {
+	return c.CreateBackupCtx(context.Background(), identifier, fields)
+}
+
+func (c *Client) CreateBackupCtx(ctx context.Context, identifier string, fields BackupDescriptor) (*Backup, error) {
+	// The panel takes the ignored files as a single newline separated string
+	data, _ := json.Marshal(struct {
+		Name     string `json:"name,omitempty"`
+		Ignored  string `json:"ignored,omitempty"`
+		IsLocked bool   `json:"is_locked"`
+	}{fields.Name, strings.Join(fields.IgnoredFiles, "\n"), fields.Locked})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/backups", identifier), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Backup `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+func (c *Client) GetBackup(identifier, uuid string) (*Backup, error) {
+	return c.GetBackupCtx(context.Background(), identifier, uuid)
+}
+
+func (c *Client) GetBackupCtx(ctx context.Context, identifier, uuid string) (*Backup, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/backups/%s", identifier, uuid), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Backup `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+// DownloadBackup returns a Downloader for the backup archive, saved as "<uuid>.tar.gz".
+func (c *Client) DownloadBackup(identifier, uuid string) (*Downloader, error) {
+	return c.DownloadBackupCtx(context.Background(), identifier, uuid)
+}
+
+func (c *Client) DownloadBackupCtx(ctx context.Context, identifier, uuid string) (*Downloader, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/backups/%s/download", identifier, uuid), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes struct {
+			URL string `json:"url"`
+		} `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	dl := &Downloader{
+		client: c,
+		Name:   uuid + ".tar.gz",
+		url:    model.Attributes.URL,
+	}
+
+	return dl, nil
+}
+
+// RestoreBackup restores the backup over the server files. With truncate, every file is
+// deleted before the backup is restored.
+func (c *Client) RestoreBackup(identifier, uuid string, truncate bool) error {
+	return c.RestoreBackupCtx(context.Background(), identifier, uuid, truncate)
+}
+
+func (c *Client) RestoreBackupCtx(ctx context.Context, identifier, uuid string, truncate bool) error {
+	data, _ := json.Marshal(map[string]bool{"truncate": truncate})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/backups/%s/restore", identifier, uuid), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+// ToggleBackupLock locks an unlocked backup and unlocks a locked one.
+func (c *Client) ToggleBackupLock(identifier, uuid string) (*Backup, error) {
+	return c.ToggleBackupLockCtx(context.Background(), identifier, uuid)
+}
+
+func (c *Client) ToggleBackupLockCtx(ctx context.Context, identifier, uuid string) (*Backup, error) {
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/backups/%s/lock", identifier, uuid), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Backup `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+func (c *Client) DeleteBackup(identifier, uuid string) error {
+	return c.DeleteBackupCtx(context.Background(), identifier, uuid)
+}
+
+func (c *Client) DeleteBackupCtx(ctx context.Context, identifier, uuid string) error {
+	req := c.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%s/backups/%s", identifier, uuid), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
@@ -0,0 +1,89 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackups(t *testing.T) {
+	p := newPanel(t)
+
+	srv := p.AddServer(&alligator.AppServer{
+		ID: 2, Name: "backed-up", UserID: 1, Allocation: 2, NestID: 1, EggID: 1,
+		FeatureLimits: alligator.FeatureLimits{Backups: 2},
+	})
+	id := srv.Identifier
+	p.AddFile(id, "world/level.dat", []byte("level"))
+	p.AddFile(id, "logs/latest.log", []byte("log"))
+
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(1))
+	if _, err := client.CreateBackup(survival, alligator.BackupDescriptor{}); err == nil {
+		t.Error("expected backups to be disabled without a limit")
+	}
+
+	b, err := client.CreateBackup(id, alligator.BackupDescriptor{Name: "nightly", IgnoredFiles: []string{"logs", "*.tmp"}, Locked: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Name != "nightly" || !b.IsSuccessful || !b.IsLocked || len(b.IgnoredFiles) != 2 || b.Checksum == "" || b.CompletedAt == nil {
+		t.Errorf("unexpected backup: %+v", b)
+	}
+	if err = client.DeleteBackup(id, b.UUID); err == nil {
+		t.Error("expected locked backups to be kept")
+	}
+	if b, err = client.ToggleBackupLock(id, b.UUID); err != nil || b.IsLocked {
+		t.Fatalf("expected the backup to be unlocked: %v %+v", err, b)
+	}
+
+	if _, err = client.CreateBackup(id, alligator.BackupDescriptor{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.CreateBackup(id, alligator.BackupDescriptor{}); err == nil {
+		t.Error("expected the backup limit to be enforced")
+	}
+	backups, err := client.ListAllBackups(id, options.ListBackupsOptions{PerPage: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[0].UUID != b.UUID {
+		t.Errorf("unexpected backups: %+v", backups)
+	}
+
+	dl, err := client.DownloadBackup(id, b.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dl.Name = filepath.Join(t.TempDir(), dl.Name)
+	if err = dl.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(dl.Name); err != nil || info.Size() != b.Bytes {
+		t.Errorf("expected a %d bytes archive: %v %v", b.Bytes, err, info)
+	}
+
+	client.WriteServerFile(id, "world/level.dat", "corrupted")
+	client.WriteServerFile(id, "world/new.dat", "new")
+	if err = client.RestoreBackup(id, b.UUID, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := p.File(id, "world/level.dat"); string(data) != "level" {
+		t.Errorf("expected the file to be restored, got %q", data)
+	}
+	if _, ok := p.File(id, "world/new.dat"); ok {
+		t.Error("expected truncate to remove files missing from the backup")
+	}
+	if _, ok := p.File(id, "logs/latest.log"); ok {
+		t.Error("expected ignored files to be left out of the backup")
+	}
+
+	if err = client.DeleteBackup(id, b.UUID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetBackup(id, b.UUID); !errors.Is(err, alligator.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after deleting, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package alligator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ClientAllocation is an allocation assigned to a server, as the client API returns it.
+type ClientAllocation struct {
+	ID        int    `json:"id"`
+	IP        string `json:"ip"`
+	Alias     string `json:"ip_alias"`
+	Port      int32  `json:"port"`
+	Notes     string `json:"notes"`
+	IsDefault bool   `json:"is_default"` // The primary allocation of the server
+}
+
+// Address returns "ip:port", using the alias when there's one.
+func (a *ClientAllocation) Address() string {
+	host := a.IP
+	if a.Alias != "" {
+		host = a.Alias
+	}
+	return fmt.Sprintf("%s:%d", host, a.Port)
+}
+
+func (c *Client) ListAllocations(identifier string) ([]*ClientAllocation, error) {
+	return c.ListAllocationsCtx(context.Background(), identifier)
+}
+
+func (c *Client) ListAllocationsCtx(ctx context.Context, identifier string) ([]*ClientAllocation, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/network/allocations", identifier), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *ClientAllocation `json:"attributes"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	allocations := make([]*ClientAllocation, 0, len(model.Data))
+	for _, a := range model.Data {
+		allocations = append(allocations, a.Attributes)
+	}
+
+	return allocations, nil
+}
+
+// AssignAllocation assigns a free allocation of the node to the server. Auto-allocation must be
+// enabled on the panel and the server must be below its allocation limit.
+func (c *Client) AssignAllocation(identifier string) (*ClientAllocation, error) {
+	return c.AssignAllocationCtx(context.Background(), identifier)
+}
+
+func (c *Client) AssignAllocationCtx(ctx context.Context, identifier string) (*ClientAllocation, error) {
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/network/allocations", identifier), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes ClientAllocation `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+func (c *Client) SetPrimaryAllocation(identifier string, id int) (*ClientAllocation, error) {
+	return c.SetPrimaryAllocationCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) SetPrimaryAllocationCtx(ctx context.Context, identifier string, id int) (*ClientAllocation, error) {
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/network/allocations/%d/primary", identifier, id), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes ClientAllocation `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+// UpdateAllocationNotes sets the notes of an allocation, an empty string clears them.
+func (c *Client) UpdateAllocationNotes(identifier string, id int, notes string) (*ClientAllocation, error) {
+	return c.UpdateAllocationNotesCtx(context.Background(), identifier, id, notes)
+}
+
+func (c *Client) UpdateAllocationNotesCtx(ctx context.Context, identifier string, id int, notes string) (*ClientAllocation, error) {
+	var value *string
+	if notes != "" {
+		value = &notes
+	}
+	data, _ := json.Marshal(map[string]*string{"notes": value})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/network/allocations/%d", identifier, id), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes ClientAllocation `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+// DeleteAllocation unassigns an allocation from the server. The primary allocation can't be deleted,
+// and the panel refuses when the server has no allocation limit.
+func (c *Client) DeleteAllocation(identifier string, id int) error {
+	return c.DeleteAllocationCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) DeleteAllocationCtx(ctx context.Context, identifier string, id int) error {
+	req := c.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%s/network/allocations/%d", identifier, id), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
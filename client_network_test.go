@@ -0,0 +1,67 @@
+package alligator_test
+
+import (
+	"github.com/m41denx/alligator"
+	"testing"
+)
+
+func TestClientAllocations(t *testing.T) {
+	p := newPanel(t)
+
+	id := survival
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(1))
+	if _, err := client.AssignAllocation(id); err == nil {
+		t.Error("expected the allocation limit to be enforced")
+	}
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	if _, err := app.UpdateServerBuild(1, alligator.ServerBuildDescriptor{FeatureLimits: &alligator.FeatureLimits{Allocations: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	extra, err := client.AssignAllocation(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extra.ID != 2 || extra.IsDefault || extra.Address() != "10.0.0.1:25566" {
+		t.Errorf("unexpected allocation: %+v", extra)
+	}
+	if _, err = client.AssignAllocation(id); err == nil {
+		t.Error("expected the limit to be reached")
+	}
+
+	if extra, err = client.UpdateAllocationNotes(id, extra.ID, "voice chat"); err != nil || extra.Notes != "voice chat" {
+		t.Errorf("expected the notes to be set: %v %+v", err, extra)
+	}
+	if err = client.DeleteAllocation(id, 1); err == nil {
+		t.Error("expected the primary allocation to be kept")
+	}
+	if extra, err = client.SetPrimaryAllocation(id, extra.ID); err != nil || !extra.IsDefault {
+		t.Fatalf("expected the allocation to become primary: %v %+v", err, extra)
+	}
+
+	srv, err := client.GetServer(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Allocations) != 2 || srv.PrimaryAllocation() == nil || srv.PrimaryAllocation().ID != 2 || srv.PrimaryAllocation().Notes != "voice chat" {
+		t.Errorf("allocations were not decoded: %+v", srv.Allocations)
+	}
+
+	if err = client.DeleteAllocation(id, 1); err != nil {
+		t.Fatal(err)
+	}
+	allocations, err := client.ListAllocations(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allocations) != 1 || allocations[0].ID != 2 {
+		t.Errorf("unexpected allocations: %+v", allocations)
+	}
+	servers, err := client.GetServers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 1 || len(servers[0].Allocations) != 1 {
+		t.Errorf("expected GetServers to include allocations: %+v", servers)
+	}
+}
@@ -0,0 +1,321 @@
+package alligator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	TaskActionCommand = "command" // Payload is the console command
+	TaskActionPower   = "power"   // Payload is the power signal: start, stop, restart or kill
+	TaskActionBackup  = "backup"  // Payload lists the ignored files, one per line
+)
+
+type Schedule struct {
+	ID             int          `json:"id"`
+	Name           string       `json:"name"`
+	Cron           ScheduleCron `json:"cron"`
+	IsActive       bool         `json:"is_active"`
+	IsProcessing   bool         `json:"is_processing"`
+	OnlyWhenOnline bool         `json:"only_when_online"`
+	LastRunAt      *time.Time   `json:"last_run_at"`
+	NextRunAt      *time.Time   `json:"next_run_at"`
+	CreatedAt      *time.Time   `json:"created_at"`
+	UpdatedAt      *time.Time   `json:"updated_at"`
+	Tasks          []*Task      `json:"-"`
+}
+
+func (s *Schedule) UpdateDescriptor() *ScheduleDescriptor {
+	return &ScheduleDescriptor{
+		Name:           s.Name,
+		ScheduleCron:   s.Cron,
+		IsActive:       s.IsActive,
+		OnlyWhenOnline: s.OnlyWhenOnline,
+	}
+}
+
+type Task struct {
+	ID                int        `json:"id"`
+	SequenceID        int        `json:"sequence_id"`
+	Action            string     `json:"action"`
+	Payload           string     `json:"payload"`
+	TimeOffset        int        `json:"time_offset"` // Seconds to wait after the previous task
+	IsQueued          bool       `json:"is_queued"`
+	ContinueOnFailure bool       `json:"continue_on_failure"`
+	CreatedAt         *time.Time `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
+
+func (t *Task) UpdateDescriptor() *TaskDescriptor {
+	return &TaskDescriptor{
+		Action:            t.Action,
+		Payload:           t.Payload,
+		TimeOffset:        t.TimeOffset,
+		SequenceID:        t.SequenceID,
+		ContinueOnFailure: t.ContinueOnFailure,
+	}
+}
+
+type ResponseSchedule struct {
+	*Schedule
+	Relationships struct {
+		Tasks struct {
+			Data []struct {
+				Attributes *Task `json:"attributes"`
+			} `json:"data"`
+		} `json:"tasks"`
+	} `json:"relationships"`
+}
+
+func (r *ResponseSchedule) getSchedule() *Schedule {
+	schedule := r.Schedule
+	schedule.Tasks = make([]*Task, 0, len(r.Relationships.Tasks.Data))
+	for _, t := range r.Relationships.Tasks.Data {
+		schedule.Tasks = append(schedule.Tasks, t.Attributes)
+	}
+	return schedule
+}
+
+func (c *Client) ListSchedules(identifier string) ([]*Schedule, error) {
+	return c.ListSchedulesCtx(context.Background(), identifier)
+}
+
+func (c *Client) ListSchedulesCtx(ctx context.Context, identifier string) ([]*Schedule, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/schedules", identifier), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *ResponseSchedule `json:"attributes"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*Schedule, 0, len(model.Data))
+	for _, s := range model.Data {
+		schedules = append(schedules, s.Attributes.getSchedule())
+	}
+
+	return schedules, nil
+}
+
+func (c *Client) GetSchedule(identifier string, id int) (*Schedule, error) {
+	return c.GetScheduleCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) GetScheduleCtx(ctx context.Context, identifier string, id int) (*Schedule, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/schedules/%d", identifier, id), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseSchedule `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getSchedule(), nil
+}
+
+// ScheduleDescriptor creates or updates a schedule, the cron fields are sent flattened.
+type ScheduleDescriptor struct {
+	Name string `json:"name"`
+	ScheduleCron
+	IsActive       bool `json:"is_active"`
+	OnlyWhenOnline bool `json:"only_when_online"` // Skip the run when the server is offline
+}
+
+func (c *Client) CreateSchedule(identifier string, fields ScheduleDescriptor) (*Schedule, error) {
+	return c.CreateScheduleCtx(context.Background(), identifier, fields)
+}
+
+func (c *Client) CreateScheduleCtx(ctx context.Context, identifier string, fields ScheduleDescriptor) (*Schedule, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/schedules", identifier), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseSchedule `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getSchedule(), nil
+}
+
+func (c *Client) UpdateSchedule(identifier string, id int, fields ScheduleDescriptor) (*Schedule, error) {
+	return c.UpdateScheduleCtx(context.Background(), identifier, id, fields)
+}
+
+func (c *Client) UpdateScheduleCtx(ctx context.Context, identifier string, id int, fields ScheduleDescriptor) (*Schedule, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/schedules/%d", identifier, id), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes *ResponseSchedule `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.getSchedule(), nil
+}
+
+// ExecuteSchedule runs the schedule's tasks now, regardless of its cron and whether it's active.
+func (c *Client) ExecuteSchedule(identifier string, id int) error {
+	return c.ExecuteScheduleCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) ExecuteScheduleCtx(ctx context.Context, identifier string, id int) error {
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/schedules/%d/execute", identifier, id), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+func (c *Client) DeleteSchedule(identifier string, id int) error {
+	return c.DeleteScheduleCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) DeleteScheduleCtx(ctx context.Context, identifier string, id int) error {
+	req := c.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%s/schedules/%d", identifier, id), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+type TaskDescriptor struct {
+	Action            string `json:"action"` // One of the TaskAction constants
+	Payload           string `json:"payload"`
+	TimeOffset        int    `json:"time_offset"`           // Seconds to wait after the previous task, up to 900
+	SequenceID        int    `json:"sequence_id,omitempty"` // Position in the schedule, appended at the end when 0
+	ContinueOnFailure bool   `json:"continue_on_failure"`
+}
+
+func (c *Client) CreateTask(identifier string, schedule int, fields TaskDescriptor) (*Task, error) {
+	return c.CreateTaskCtx(context.Background(), identifier, schedule, fields)
+}
+
+func (c *Client) CreateTaskCtx(ctx context.Context, identifier string, schedule int, fields TaskDescriptor) (*Task, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/schedules/%d/tasks", identifier, schedule), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Task `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+func (c *Client) UpdateTask(identifier string, schedule, id int, fields TaskDescriptor) (*Task, error) {
+	return c.UpdateTaskCtx(context.Background(), identifier, schedule, id, fields)
+}
+
+func (c *Client) UpdateTaskCtx(ctx context.Context, identifier string, schedule, id int, fields TaskDescriptor) (*Task, error) {
+	data, _ := json.Marshal(fields)
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/schedules/%d/tasks/%d", identifier, schedule, id), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Task `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+func (c *Client) DeleteTask(identifier string, schedule, id int) error {
+	return c.DeleteTaskCtx(context.Background(), identifier, schedule, id)
+}
+
+func (c *Client) DeleteTaskCtx(ctx context.Context, identifier string, schedule, id int) error {
+	req := c.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%s/schedules/%d/tasks/%d", identifier, schedule, id), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
@@ -0,0 +1,93 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"testing"
+	"time"
+)
+
+func TestSchedules(t *testing.T) {
+	p := newPanel(t)
+
+	id := survival
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(1))
+
+	cron, _ := alligator.ParseCron("0 */6 * * *")
+	if _, err := client.CreateSchedule(id, alligator.ScheduleDescriptor{Name: "broken", ScheduleCron: alligator.ScheduleCron{
+		Minute: "61", Hour: "*", DayOfMonth: "*", Month: "*", DayOfWeek: "*",
+	}}); err == nil {
+		t.Error("expected an invalid cron to be rejected")
+	}
+	sc, err := client.CreateSchedule(id, alligator.ScheduleDescriptor{Name: "restart", ScheduleCron: cron, IsActive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, _ := cron.Next(time.Now().UTC())
+	if sc.Cron != cron || sc.NextRunAt == nil || !sc.NextRunAt.Equal(next) || len(sc.Tasks) != 0 {
+		t.Errorf("unexpected schedule: %+v", sc)
+	}
+
+	if _, err = client.CreateTask(id, sc.ID, alligator.TaskDescriptor{Action: alligator.TaskActionBackup}); err == nil {
+		t.Error("expected backup tasks to be rejected without a backup limit")
+	}
+	if _, err = client.CreateTask(id, sc.ID, alligator.TaskDescriptor{Action: alligator.TaskActionCommand}); !errors.Is(err, alligator.ErrValidation) {
+		t.Errorf("expected a missing payload to fail validation, got %v", err)
+	}
+	say, err := client.CreateTask(id, sc.ID, alligator.TaskDescriptor{Action: alligator.TaskActionCommand, Payload: "say restarting"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, err := client.CreateTask(id, sc.ID, alligator.TaskDescriptor{Action: alligator.TaskActionPower, Payload: "start", SequenceID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc, err = client.GetSchedule(id, sc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(sc.Tasks) != 2 || sc.Tasks[0].ID != start.ID || sc.Tasks[1].ID != say.ID || sc.Tasks[1].SequenceID != 2 {
+		t.Errorf("expected the power task to be inserted first: %+v %+v", sc.Tasks[0], sc.Tasks[1])
+	}
+
+	if err = client.ExecuteSchedule(id, sc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if p.PowerState(id) != "running" || len(p.Commands(id)) != 1 || p.Commands(id)[0] != "say restarting" {
+		t.Errorf("expected the tasks to run in sequence: %s %q", p.PowerState(id), p.Commands(id))
+	}
+	if sc, err = client.GetSchedule(id, sc.ID); err != nil || sc.LastRunAt == nil {
+		t.Errorf("expected the last run to be recorded: %v %+v", err, sc)
+	}
+
+	desc := sc.UpdateDescriptor()
+	desc.IsActive = false
+	if sc, err = client.UpdateSchedule(id, sc.ID, *desc); err != nil || sc.IsActive {
+		t.Errorf("expected the schedule to be paused: %v %+v", err, sc)
+	}
+	for i := len(sc.Tasks); i < 10; i++ {
+		if _, err = client.CreateTask(id, sc.ID, alligator.TaskDescriptor{Action: alligator.TaskActionCommand, Payload: "list"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = client.CreateTask(id, sc.ID, alligator.TaskDescriptor{Action: alligator.TaskActionCommand, Payload: "list"}); err == nil {
+		t.Error("expected the task limit to be enforced")
+	}
+
+	if err = client.DeleteTask(id, sc.ID, start.ID); err != nil {
+		t.Fatal(err)
+	}
+	schedules, err := client.ListSchedules(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedules) != 1 || len(schedules[0].Tasks) != 9 || schedules[0].Tasks[0].ID != say.ID || schedules[0].Tasks[0].SequenceID != 1 {
+		t.Errorf("expected the remaining tasks to be renumbered: %+v", schedules)
+	}
+
+	if err = client.DeleteSchedule(id, sc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.GetSchedule(id, sc.ID); !errors.Is(err, alligator.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after deleting, got %v", err)
+	}
+}
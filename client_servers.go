@@ -2,11 +2,13 @@ package alligator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -40,21 +42,61 @@ type ClientServer struct {
 		IP   string `json:"ip"`
 		Port int64  `json:"port"`
 	} `json:"sftp_details"`
-	Description   string        `json:"description"`
-	Limits        Limits        `json:"limits"`
-	Invocation    string        `json:"invocation"`
-	DockerImage   string        `json:"docker_image"`
-	EggFeatures   []string      `json:"egg_features"`
-	FeatureLimits FeatureLimits `json:"feature_limits"`
-	Status        string        `json:"status"`
-	Suspended     bool          `json:"is_suspended"`
-	Installing    bool          `json:"is_installing"`
-	Transferring  bool          `json:"is_transferring"`
+	Description   string              `json:"description"`
+	Limits        Limits              `json:"limits"`
+	Invocation    string              `json:"invocation"`
+	DockerImage   string              `json:"docker_image"`
+	EggFeatures   []string            `json:"egg_features"`
+	FeatureLimits FeatureLimits       `json:"feature_limits"`
+	Status        string              `json:"status"`
+	Suspended     bool                `json:"is_suspended"`
+	Installing    bool                `json:"is_installing"`
+	Transferring  bool                `json:"is_transferring"`
+	Allocations   []*ClientAllocation `json:"-"`
+}
+
+type ResponseClientServer struct {
+	*ClientServer
+	Relationships struct {
+		Allocations struct {
+			Data []struct {
+				Attributes *ClientAllocation `json:"attributes"`
+			} `json:"data"`
+		} `json:"allocations"`
+	} `json:"relationships"`
+}
+
+func (r *ResponseClientServer) getServer() (*ClientServer, error) {
+	if r == nil || r.ClientServer == nil {
+		return nil, errors.New("empty server attributes")
+	}
+	server := r.ClientServer
+	server.Allocations = make([]*ClientAllocation, 0, len(r.Relationships.Allocations.Data))
+	for _, a := range r.Relationships.Allocations.Data {
+		if a.Attributes != nil {
+			server.Allocations = append(server.Allocations, a.Attributes)
+		}
+	}
+	return server, nil
+}
+
+// PrimaryAllocation returns the default allocation of the server, nil when allocations weren't returned.
+func (s *ClientServer) PrimaryAllocation() *ClientAllocation {
+	for _, a := range s.Allocations {
+		if a.IsDefault {
+			return a
+		}
+	}
+	return nil
 }
 
 func (c *Client) GetServers() ([]*ClientServer, error) {
-	req := c.newRequest("GET", "", nil)
-	res, err := c.Http.Do(req)
+	return c.GetServersCtx(context.Background())
+}
+
+func (c *Client) GetServersCtx(ctx context.Context) ([]*ClientServer, error) {
+	req := c.newRequest(ctx, "GET", "", nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +108,7 @@ func (c *Client) GetServers() ([]*ClientServer, error) {
 
 	var model struct {
 		Data []struct {
-			Attributes *ClientServer `json:"attributes"`
+			Attributes *ResponseClientServer `json:"attributes"`
 		} `json:"data"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
@@ -75,15 +117,23 @@ func (c *Client) GetServers() ([]*ClientServer, error) {
 
 	servers := make([]*ClientServer, 0, len(model.Data))
 	for _, s := range model.Data {
-		servers = append(servers, s.Attributes)
+		server, err := s.Attributes.getServer()
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, server)
 	}
 
 	return servers, nil
 }
 
 func (c *Client) GetServer(identifier string) (*ClientServer, error) {
-	req := c.newRequest("GET", "/servers/"+identifier, nil)
-	res, err := c.Http.Do(req)
+	return c.GetServerCtx(context.Background(), identifier)
+}
+
+func (c *Client) GetServerCtx(ctx context.Context, identifier string) (*ClientServer, error) {
+	req := c.newRequest(ctx, "GET", "/servers/"+identifier, nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +144,13 @@ func (c *Client) GetServer(identifier string) (*ClientServer, error) {
 	}
 
 	var model struct {
-		Attributes ClientServer `json:"attributes"`
+		Attributes *ResponseClientServer `json:"attributes"`
 	}
 	if err = json.Unmarshal(buf, &model); err != nil {
 		return nil, err
 	}
 
-	return &model.Attributes, err
+	return model.Attributes.getServer()
 }
 
 type WebSocketAuth struct {
@@ -109,8 +159,12 @@ type WebSocketAuth struct {
 }
 
 func (c *Client) GetServerWebSocket(identifier string) (*WebSocketAuth, error) {
-	req := c.newRequest("GET", fmt.Sprintf("/servers/%s/websocket", identifier), nil)
-	res, err := c.Http.Do(req)
+	return c.GetServerWebSocketCtx(context.Background(), identifier)
+}
+
+func (c *Client) GetServerWebSocketCtx(ctx context.Context, identifier string) (*WebSocketAuth, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/websocket", identifier), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -146,8 +200,12 @@ type Resources struct {
 }
 
 func (c *Client) GetServerResources(identifier string) (*Resources, error) {
-	req := c.newRequest("GET", fmt.Sprintf("/servers/%s/resources", identifier), nil)
-	res, err := c.Http.Do(req)
+	return c.GetServerResourcesCtx(context.Background(), identifier)
+}
+
+func (c *Client) GetServerResourcesCtx(ctx context.Context, identifier string) (*Resources, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/resources", identifier), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -168,12 +226,16 @@ func (c *Client) GetServerResources(identifier string) (*Resources, error) {
 }
 
 func (c *Client) SendServerCommand(identifier, command string) error {
+	return c.SendServerCommandCtx(context.Background(), identifier, command)
+}
+
+func (c *Client) SendServerCommandCtx(ctx context.Context, identifier, command string) error {
 	data, _ := json.Marshal(map[string]string{"command": command})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/command", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/command", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -183,12 +245,16 @@ func (c *Client) SendServerCommand(identifier, command string) error {
 }
 
 func (c *Client) SetServerPowerState(identifier, state string) error {
+	return c.SetServerPowerStateCtx(context.Background(), identifier, state)
+}
+
+func (c *Client) SetServerPowerStateCtx(ctx context.Context, identifier, state string) error {
 	data, _ := json.Marshal(map[string]string{"signal": state})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/power", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/power", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -210,8 +276,12 @@ type ClientDatabase struct {
 }
 
 func (c *Client) GetServerDatabases(identifier string) ([]*ClientDatabase, error) {
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/command", identifier), nil)
-	res, err := c.Http.Do(req)
+	return c.GetServerDatabasesCtx(context.Background(), identifier)
+}
+
+func (c *Client) GetServerDatabasesCtx(ctx context.Context, identifier string) ([]*ClientDatabase, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/databases", identifier), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -239,12 +309,16 @@ func (c *Client) GetServerDatabases(identifier string) ([]*ClientDatabase, error
 }
 
 func (c *Client) CreateDatabase(identifier, remote, database string) (*ClientDatabase, error) {
+	return c.CreateDatabaseCtx(context.Background(), identifier, remote, database)
+}
+
+func (c *Client) CreateDatabaseCtx(ctx context.Context, identifier, remote, database string) (*ClientDatabase, error) {
 	data, _ := json.Marshal(map[string]string{"remote": remote, "database": database})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/databases", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/databases", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -265,8 +339,12 @@ func (c *Client) CreateDatabase(identifier, remote, database string) (*ClientDat
 }
 
 func (c *Client) RotateDatabasePassword(identifier, id string) (*ClientDatabase, error) {
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/databases/%s/rotate-password", identifier, id), nil)
-	res, err := c.Http.Do(req)
+	return c.RotateDatabasePasswordCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) RotateDatabasePasswordCtx(ctx context.Context, identifier, id string) (*ClientDatabase, error) {
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/databases/%s/rotate-password", identifier, id), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -287,8 +365,12 @@ func (c *Client) RotateDatabasePassword(identifier, id string) (*ClientDatabase,
 }
 
 func (c *Client) DeleteDatabase(identifier, id string) error {
-	req := c.newRequest("DELETE", fmt.Sprintf("/servers/%s/databases/%s", identifier, id), nil)
-	res, err := c.Http.Do(req)
+	return c.DeleteDatabaseCtx(context.Background(), identifier, id)
+}
+
+func (c *Client) DeleteDatabaseCtx(ctx context.Context, identifier, id string) error {
+	req := c.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%s/databases/%s", identifier, id), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -310,8 +392,12 @@ type File struct {
 }
 
 func (c *Client) GetServerFiles(identififer, root string) ([]*File, error) {
-	req := c.newRequest("GET", fmt.Sprintf("/servers/%s/files/list?directory=%s", identififer, url.PathEscape(root)), nil)
-	res, err := c.Http.Do(req)
+	return c.GetServerFilesCtx(context.Background(), identififer, root)
+}
+
+func (c *Client) GetServerFilesCtx(ctx context.Context, identififer, root string) ([]*File, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/files/list?directory=%s", identififer, url.PathEscape(root)), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -339,10 +425,14 @@ func (c *Client) GetServerFiles(identififer, root string) ([]*File, error) {
 }
 
 func (c *Client) GetServerFileContents(identifier, file string) ([]byte, error) {
-	req := c.newRequest("GET", fmt.Sprintf("/servers/%s/files/contents?file=%s", identifier, url.PathEscape(file)), nil)
+	return c.GetServerFileContentsCtx(context.Background(), identifier, file)
+}
+
+func (c *Client) GetServerFileContentsCtx(ctx context.Context, identifier, file string) ([]byte, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/files/contents?file=%s", identifier, url.PathEscape(file)), nil)
 	req.Header.Set("Accept", "application/json,text/plain")
 
-	res, err := c.Http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -366,6 +456,10 @@ func (d *Downloader) URL() string {
 }
 
 func (d *Downloader) Execute() error {
+	return d.ExecuteCtx(context.Background())
+}
+
+func (d *Downloader) ExecuteCtx(ctx context.Context) error {
 	info, err := os.Stat(d.Path)
 	if err == nil {
 		if !info.IsDir() {
@@ -373,11 +467,17 @@ func (d *Downloader) Execute() error {
 		}
 	}
 
-	res, err := d.client.Http.Get(d.URL())
+	req, err := http.NewRequestWithContext(ctx, "GET", d.URL(), nil)
 	if err != nil {
 		return err
 	}
 
+	res, err := chain(d.client.Http.Do, d.client.Middleware)(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
 	if res.StatusCode != 200 {
 		return fmt.Errorf("recieved an unexpected response: %s", res.Status)
 	}
@@ -386,14 +486,18 @@ func (d *Downloader) Execute() error {
 	if err != nil {
 		return err
 	}
+	defer file.Close()
 
-	defer res.Body.Close()
-	io.Copy(file, res.Body)
-	return nil
+	_, err = io.Copy(file, res.Body)
+	return err
 }
 
 func (c *Client) DownloadServerFile(identifier, file string) (*Downloader, error) {
-	files, err := c.GetServerFiles(identifier, "/")
+	return c.DownloadServerFileCtx(context.Background(), identifier, file)
+}
+
+func (c *Client) DownloadServerFileCtx(ctx context.Context, identifier, file string) (*Downloader, error) {
+	files, err := c.GetServerFilesCtx(ctx, identifier, "/")
 	if err != nil {
 		return nil, err
 	}
@@ -409,8 +513,8 @@ func (c *Client) DownloadServerFile(identifier, file string) (*Downloader, error
 		}
 	}
 
-	req := c.newRequest("GET", fmt.Sprintf("/servers/%s/files/download?file=%s", identifier, url.PathEscape(file)), nil)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/files/download?file=%s", identifier, url.PathEscape(file)), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -449,12 +553,16 @@ type RenameDescriptor struct {
 }
 
 func (c *Client) RenameServerFiles(identifier string, files RenameDescriptor) error {
+	return c.RenameServerFilesCtx(context.Background(), identifier, files)
+}
+
+func (c *Client) RenameServerFilesCtx(ctx context.Context, identifier string, files RenameDescriptor) error {
 	data, _ := json.Marshal(files)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("PUT", fmt.Sprintf("/servers/%s/files/rename", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "PUT", fmt.Sprintf("/servers/%s/files/rename", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -464,12 +572,16 @@ func (c *Client) RenameServerFiles(identifier string, files RenameDescriptor) er
 }
 
 func (c *Client) CopyServerFile(identifier, location string) error {
+	return c.CopyServerFileCtx(context.Background(), identifier, location)
+}
+
+func (c *Client) CopyServerFileCtx(ctx context.Context, identifier, location string) error {
 	data, _ := json.Marshal(map[string]string{"location": location})
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/copy", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/copy", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -479,12 +591,16 @@ func (c *Client) CopyServerFile(identifier, location string) error {
 }
 
 func (c *Client) WriteServerFileBytes(identifier, name, header string, content []byte) error {
+	return c.WriteServerFileBytesCtx(context.Background(), identifier, name, header, content)
+}
+
+func (c *Client) WriteServerFileBytesCtx(ctx context.Context, identifier, name, header string, content []byte) error {
 	body := bytes.Buffer{}
 	body.Write(content)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/write?file=%s", identifier, url.PathEscape(name)), &body)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/write?file=%s", identifier, url.PathEscape(name)), &body)
 	req.Header.Set("Content-Type", header)
-	res, err := c.Http.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -494,7 +610,11 @@ func (c *Client) WriteServerFileBytes(identifier, name, header string, content [
 }
 
 func (c *Client) WriteServerFile(identifier, name, content string) error {
-	return c.WriteServerFileBytes(identifier, name, "text/plain", []byte(content))
+	return c.WriteServerFileCtx(context.Background(), identifier, name, content)
+}
+
+func (c *Client) WriteServerFileCtx(ctx context.Context, identifier, name, content string) error {
+	return c.WriteServerFileBytesCtx(ctx, identifier, name, "text/plain", []byte(content))
 }
 
 type CompressDescriptor struct {
@@ -503,12 +623,16 @@ type CompressDescriptor struct {
 }
 
 func (c *Client) CompressServerFiles(identifier string, files CompressDescriptor) error {
+	return c.CompressServerFilesCtx(context.Background(), identifier, files)
+}
+
+func (c *Client) CompressServerFilesCtx(ctx context.Context, identifier string, files CompressDescriptor) error {
 	data, _ := json.Marshal(files)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/compress", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/compress", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -523,12 +647,16 @@ type DecompressDescriptor struct {
 }
 
 func (c *Client) DecompressServerFile(identifier string, file DecompressDescriptor) error {
+	return c.DecompressServerFileCtx(context.Background(), identifier, file)
+}
+
+func (c *Client) DecompressServerFileCtx(ctx context.Context, identifier string, file DecompressDescriptor) error {
 	data, _ := json.Marshal(file)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/decompress", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/decompress", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -543,12 +671,16 @@ type DeleteFilesDescriptor struct {
 }
 
 func (c *Client) DeleteServerFiles(identifier string, files DeleteFilesDescriptor) error {
+	return c.DeleteServerFilesCtx(context.Background(), identifier, files)
+}
+
+func (c *Client) DeleteServerFilesCtx(ctx context.Context, identifier string, files DeleteFilesDescriptor) error {
 	data, _ := json.Marshal(files)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/delete", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/delete", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -563,12 +695,16 @@ type CreateFolderDescriptor struct {
 }
 
 func (c *Client) CreateServerFileFolder(identifier string, file CreateFolderDescriptor) error {
+	return c.CreateServerFileFolderCtx(context.Background(), identifier, file)
+}
+
+func (c *Client) CreateServerFileFolderCtx(ctx context.Context, identifier string, file CreateFolderDescriptor) error {
 	data, _ := json.Marshal(file)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/create-folder", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/create-folder", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -586,12 +722,16 @@ type ChmodDescriptor struct {
 }
 
 func (c *Client) ChmodServerFiles(identifier string, files ChmodDescriptor) error {
+	return c.ChmodServerFilesCtx(context.Background(), identifier, files)
+}
+
+func (c *Client) ChmodServerFilesCtx(ctx context.Context, identifier string, files ChmodDescriptor) error {
 	data, _ := json.Marshal(files)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/chmod", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/chmod", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -609,12 +749,16 @@ type PullDescriptor struct {
 }
 
 func (c *Client) PullServerFile(identifier string, file PullDescriptor) error {
+	return c.PullServerFileCtx(context.Background(), identifier, file)
+}
+
+func (c *Client) PullServerFileCtx(ctx context.Context, identifier string, file PullDescriptor) error {
 	data, _ := json.Marshal(file)
 	body := bytes.Buffer{}
 	body.Write(data)
 
-	req := c.newRequest("POST", fmt.Sprintf("/servers/%s/files/pull", identifier), &body)
-	res, err := c.Http.Do(req)
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/files/pull", identifier), &body)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -638,6 +782,10 @@ func (u *Uploader) URL() string {
 }
 
 func (u *Uploader) Execute() error {
+	return u.ExecuteCtx(context.Background())
+}
+
+func (u *Uploader) ExecuteCtx(ctx context.Context) error {
 	if u.Path == "" {
 		return errors.New("no file path has been specified")
 	}
@@ -661,16 +809,40 @@ func (u *Uploader) Execute() error {
 	}
 	defer file.Close()
 
-	body := bytes.Buffer{}
-	writer := multipart.NewWriter(&body)
-	part, _ := writer.CreateFormFile("files", info.Name())
-	io.Copy(part, file)
-	writer.Close()
+	// Stream the multipart body so that cancelling ctx stops the transfer
+	// instead of buffering the whole file in memory first.
+	body, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		part, err := writer.CreateFormFile("files", info.Name())
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	// The body isn't read to the end when a middleware short-circuits or the transport fails,
+	// unblock the writer and wait for it so the file isn't closed under it
+	defer func() {
+		body.CloseWithError(errors.New("upload request finished"))
+		<-done
+	}()
 
-	res, err := u.client.Http.Post(u.URL(), writer.FormDataContentType(), &body)
+	req, err := http.NewRequestWithContext(ctx, "POST", u.URL(), body)
 	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := chain(u.client.Http.Do, u.client.Middleware)(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
 		return fmt.Errorf("recieved an unexpected response: %s", res.Status)
@@ -680,8 +852,12 @@ func (u *Uploader) Execute() error {
 }
 
 func (c *Client) UploadServerFile(identifier, path string) (*Uploader, error) {
-	req := c.newRequest("GET", fmt.Sprintf("/servers/%s/files/upload", identifier), nil)
-	res, err := c.Http.Do(req)
+	return c.UploadServerFileCtx(context.Background(), identifier, path)
+}
+
+func (c *Client) UploadServerFileCtx(ctx context.Context, identifier, path string) (*Uploader, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/files/upload", identifier), nil)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -700,6 +876,6 @@ func (c *Client) UploadServerFile(identifier, path string) (*Uploader, error) {
 		return nil, err
 	}
 
-	up := &Uploader{client: c, url: model.Attributes.URL}
+	up := &Uploader{client: c, url: model.Attributes.URL, Path: path}
 	return up, nil
 }
@@ -0,0 +1,94 @@
+package alligator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestUploadServerFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/client/servers/abc/files/upload":
+			fmt.Fprintf(w, `{"object":"signed_url","attributes":{"url":"http://%s/upload"}}`, r.Host)
+		case "/upload":
+			file, header, err := r.FormFile("files")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+			if data, _ := io.ReadAll(file); header.Filename != "level.dat" || string(data) != "level" {
+				http.Error(w, "unexpected upload", http.StatusBadRequest)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	name := filepath.Join(t.TempDir(), "level.dat")
+	if err := os.WriteFile(name, []byte("level"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, _ := NewClient(srv.URL, "ptlc_test")
+	up, err := client.UploadServerFile("abc", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up.Path != name {
+		t.Errorf("expected the uploader to keep the path, got %q", up.Path)
+	}
+	if err = up.Execute(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUploaderShortCircuit(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "level.dat")
+	if err := os.WriteFile(name, []byte("level"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, _ := NewClient("http://127.0.0.1", "ptlc_test")
+	client.Use(func(next Doer) Doer {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("offline")
+		}
+	})
+
+	before := runtime.NumGoroutine()
+	up := &Uploader{client: client, url: "http://127.0.0.1/upload", Path: name}
+	if err := up.Execute(); err == nil {
+		t.Fatal("expected the middleware error")
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("the body writer is still running: %d goroutines, %d before", after, before)
+	}
+}
+
+func TestEmptyServerAttributes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/client" {
+			w.Write([]byte(`{"object":"list","data":[{"object":"server"}]}`))
+			return
+		}
+		w.Write([]byte(`{"object":"server","attributes":null}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient(srv.URL, "ptlc_test")
+	if _, err := client.GetServer("abc"); err == nil {
+		t.Error("expected an error for null attributes")
+	}
+	if _, err := client.GetServers(); err == nil {
+		t.Error("expected an error for missing attributes")
+	}
+}
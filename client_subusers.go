@@ -0,0 +1,325 @@
+package alligator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnknownPermission = errors.New("unknown permission")
+
+// Permission is a subuser permission, made of its group and key like "file.read-content".
+type Permission string
+
+const (
+	PermissionWebsocketConnect Permission = "websocket.connect"
+
+	PermissionControlConsole Permission = "control.console"
+	PermissionControlStart   Permission = "control.start"
+	PermissionControlStop    Permission = "control.stop"
+	PermissionControlRestart Permission = "control.restart"
+
+	PermissionUserCreate Permission = "user.create"
+	PermissionUserRead   Permission = "user.read"
+	PermissionUserUpdate Permission = "user.update"
+	PermissionUserDelete Permission = "user.delete"
+
+	PermissionFileCreate      Permission = "file.create"
+	PermissionFileRead        Permission = "file.read"
+	PermissionFileReadContent Permission = "file.read-content" // Also allows downloading files
+	PermissionFileUpdate      Permission = "file.update"
+	PermissionFileDelete      Permission = "file.delete"
+	PermissionFileArchive     Permission = "file.archive"
+	PermissionFileSFTP        Permission = "file.sftp"
+
+	PermissionBackupCreate   Permission = "backup.create"
+	PermissionBackupRead     Permission = "backup.read"
+	PermissionBackupDelete   Permission = "backup.delete"
+	PermissionBackupDownload Permission = "backup.download"
+	PermissionBackupRestore  Permission = "backup.restore"
+
+	PermissionAllocationRead   Permission = "allocation.read"
+	PermissionAllocationCreate Permission = "allocation.create"
+	PermissionAllocationUpdate Permission = "allocation.update"
+	PermissionAllocationDelete Permission = "allocation.delete"
+
+	PermissionStartupRead        Permission = "startup.read"
+	PermissionStartupUpdate      Permission = "startup.update"
+	PermissionStartupDockerImage Permission = "startup.docker-image"
+
+	PermissionDatabaseCreate       Permission = "database.create"
+	PermissionDatabaseRead         Permission = "database.read"
+	PermissionDatabaseUpdate       Permission = "database.update"
+	PermissionDatabaseDelete       Permission = "database.delete"
+	PermissionDatabaseViewPassword Permission = "database.view_password"
+
+	PermissionScheduleCreate Permission = "schedule.create"
+	PermissionScheduleRead   Permission = "schedule.read"
+	PermissionScheduleUpdate Permission = "schedule.update"
+	PermissionScheduleDelete Permission = "schedule.delete"
+
+	PermissionSettingsRename    Permission = "settings.rename"
+	PermissionSettingsReinstall Permission = "settings.reinstall"
+
+	PermissionActivityRead Permission = "activity.read"
+)
+
+var knownPermissions = map[Permission]bool{
+	PermissionWebsocketConnect:     true,
+	PermissionControlConsole:       true,
+	PermissionControlStart:         true,
+	PermissionControlStop:          true,
+	PermissionControlRestart:       true,
+	PermissionUserCreate:           true,
+	PermissionUserRead:             true,
+	PermissionUserUpdate:           true,
+	PermissionUserDelete:           true,
+	PermissionFileCreate:           true,
+	PermissionFileRead:             true,
+	PermissionFileReadContent:      true,
+	PermissionFileUpdate:           true,
+	PermissionFileDelete:           true,
+	PermissionFileArchive:          true,
+	PermissionFileSFTP:             true,
+	PermissionBackupCreate:         true,
+	PermissionBackupRead:           true,
+	PermissionBackupDelete:         true,
+	PermissionBackupDownload:       true,
+	PermissionBackupRestore:        true,
+	PermissionAllocationRead:       true,
+	PermissionAllocationCreate:     true,
+	PermissionAllocationUpdate:     true,
+	PermissionAllocationDelete:     true,
+	PermissionStartupRead:          true,
+	PermissionStartupUpdate:        true,
+	PermissionStartupDockerImage:   true,
+	PermissionDatabaseCreate:       true,
+	PermissionDatabaseRead:         true,
+	PermissionDatabaseUpdate:       true,
+	PermissionDatabaseDelete:       true,
+	PermissionDatabaseViewPassword: true,
+	PermissionScheduleCreate:       true,
+	PermissionScheduleRead:         true,
+	PermissionScheduleUpdate:       true,
+	PermissionScheduleDelete:       true,
+	PermissionSettingsRename:       true,
+	PermissionSettingsReinstall:    true,
+	PermissionActivityRead:         true,
+}
+
+// Valid reports whether the panel knows the permission.
+func (p Permission) Valid() bool {
+	return knownPermissions[p]
+}
+
+func validatePermissions(permissions []Permission) error {
+	for _, p := range permissions {
+		if !p.Valid() {
+			return fmt.Errorf("%w: %q", ErrUnknownPermission, p)
+		}
+	}
+	return nil
+}
+
+type Subuser struct {
+	UUID             string       `json:"uuid"` // UUID of the user, used to manage the subuser
+	Username         string       `json:"username"`
+	Email            string       `json:"email"`
+	Image            string       `json:"image"`
+	TwoFactorEnabled bool         `json:"2fa_enabled"`
+	CreatedAt        *time.Time   `json:"created_at"`
+	Permissions      []Permission `json:"permissions"`
+}
+
+// Can reports whether the subuser was given the permission.
+func (s *Subuser) Can(permission Permission) bool {
+	for _, p := range s.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) ListSubusers(identifier string) ([]*Subuser, error) {
+	return c.ListSubusersCtx(context.Background(), identifier)
+}
+
+func (c *Client) ListSubusersCtx(ctx context.Context, identifier string) ([]*Subuser, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/users", identifier), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Data []struct {
+			Attributes *Subuser `json:"attributes"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	subusers := make([]*Subuser, 0, len(model.Data))
+	for _, s := range model.Data {
+		subusers = append(subusers, s.Attributes)
+	}
+
+	return subusers, nil
+}
+
+func (c *Client) GetSubuser(identifier, uuid string) (*Subuser, error) {
+	return c.GetSubuserCtx(context.Background(), identifier, uuid)
+}
+
+func (c *Client) GetSubuserCtx(ctx context.Context, identifier, uuid string) (*Subuser, error) {
+	req := c.newRequest(ctx, "GET", fmt.Sprintf("/servers/%s/users/%s", identifier, uuid), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Subuser `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+// CreateSubuser gives the user with this email access to the server, the panel creates an account
+// when there's none yet. Permissions the requesting user doesn't have are dropped by the panel.
+func (c *Client) CreateSubuser(identifier, email string, permissions []Permission) (*Subuser, error) {
+	return c.CreateSubuserCtx(context.Background(), identifier, email, permissions)
+}
+
+func (c *Client) CreateSubuserCtx(ctx context.Context, identifier, email string, permissions []Permission) (*Subuser, error) {
+	if err := validatePermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"email": email, "permissions": permissions})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/users", identifier), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Subuser `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+// UpdateSubuser replaces the permissions of the subuser.
+func (c *Client) UpdateSubuser(identifier, uuid string, permissions []Permission) (*Subuser, error) {
+	return c.UpdateSubuserCtx(context.Background(), identifier, uuid, permissions)
+}
+
+func (c *Client) UpdateSubuserCtx(ctx context.Context, identifier, uuid string, permissions []Permission) (*Subuser, error) {
+	if err := validatePermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"permissions": permissions})
+	body := bytes.Buffer{}
+	body.Write(data)
+
+	req := c.newRequest(ctx, "POST", fmt.Sprintf("/servers/%s/users/%s", identifier, uuid), &body)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes Subuser `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return &model.Attributes, nil
+}
+
+func (c *Client) DeleteSubuser(identifier, uuid string) error {
+	return c.DeleteSubuserCtx(context.Background(), identifier, uuid)
+}
+
+func (c *Client) DeleteSubuserCtx(ctx context.Context, identifier, uuid string) error {
+	req := c.newRequest(ctx, "DELETE", fmt.Sprintf("/servers/%s/users/%s", identifier, uuid), nil)
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = validate(res)
+	return err
+}
+
+// PermissionGroup describes a group of permissions, Keys maps each key (e.g. "read-content") to its description.
+type PermissionGroup struct {
+	Description string            `json:"description"`
+	Keys        map[string]string `json:"keys"`
+}
+
+// GetPermissions returns the panel's permission catalogue, by group name (e.g. "file").
+func (c *Client) GetPermissions() (map[string]*PermissionGroup, error) {
+	return c.GetPermissionsCtx(context.Background())
+}
+
+func (c *Client) GetPermissionsCtx(ctx context.Context) (map[string]*PermissionGroup, error) {
+	req := c.newRequest(ctx, "GET", "/permissions", nil)
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := validate(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var model struct {
+		Attributes struct {
+			Permissions map[string]*PermissionGroup `json:"permissions"`
+		} `json:"attributes"`
+	}
+	if err = json.Unmarshal(buf, &model); err != nil {
+		return nil, err
+	}
+
+	return model.Attributes.Permissions, nil
+}
@@ -0,0 +1,92 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"github.com/m41denx/alligator/options"
+	"testing"
+)
+
+func TestSubusers(t *testing.T) {
+	p := newPanel(t)
+
+	id := survival
+	owner, _ := alligator.NewClient(p.URL, p.ClientKey(1))
+
+	if _, err := owner.CreateSubuser(id, "friend@example.com", []alligator.Permission{"file.explode"}); !errors.Is(err, alligator.ErrUnknownPermission) {
+		t.Errorf("expected ErrUnknownPermission, got %v", err)
+	}
+	if _, err := owner.CreateSubuser(id, "owner@example.com", []alligator.Permission{alligator.PermissionControlConsole}); err == nil {
+		t.Error("expected the owner to be rejected as a subuser")
+	}
+
+	friend, err := owner.CreateSubuser(id, "friend@example.com", []alligator.Permission{
+		alligator.PermissionControlConsole, alligator.PermissionUserCreate, alligator.PermissionFileRead,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if friend.UUID == "" || friend.Email != "friend@example.com" || !friend.Can(alligator.PermissionWebsocketConnect) || len(friend.Permissions) != 4 {
+		t.Errorf("unexpected subuser: %+v", friend)
+	}
+	if _, err = owner.CreateSubuser(id, "friend@example.com", []alligator.Permission{}); err == nil {
+		t.Error("expected a duplicate subuser to be rejected")
+	}
+
+	// Subusers can access the server and only hand out the permissions they have
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	users, err := app.ListUsers(options.ListUsersOptions{Filters: options.FiltersUsers{UUID: friend.UUID}})
+	if err != nil || len(users) != 1 {
+		t.Fatalf("expected the panel to create an account: %v %+v", err, users)
+	}
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(users[0].ID))
+	if servers, err := client.GetServers(); err != nil || len(servers) != 1 || servers[0].ServerOwner {
+		t.Fatalf("expected the subuser to see the server: %v %+v", err, servers)
+	}
+	helper, err := client.CreateSubuser(id, "admin@example.com", []alligator.Permission{alligator.PermissionFileRead, alligator.PermissionFileDelete})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if helper.Can(alligator.PermissionFileDelete) || !helper.Can(alligator.PermissionFileRead) {
+		t.Errorf("expected permissions the subuser lacks to be dropped: %v", helper.Permissions)
+	}
+	if _, err = client.UpdateSubuser(id, friend.UUID, []alligator.Permission{alligator.PermissionFileDelete}); !errors.Is(err, alligator.ErrForbidden) {
+		t.Errorf("expected subusers to be unable to edit themselves, got %v", err)
+	}
+
+	if friend, err = owner.UpdateSubuser(id, friend.UUID, []alligator.Permission{alligator.PermissionBackupRead}); err != nil {
+		t.Fatal(err)
+	}
+	if friend.Can(alligator.PermissionControlConsole) || !friend.Can(alligator.PermissionBackupRead) {
+		t.Errorf("expected the permissions to be replaced: %v", friend.Permissions)
+	}
+	if err = owner.DeleteSubuser(id, friend.UUID); err != nil {
+		t.Fatal(err)
+	}
+	subusers, err := owner.ListSubusers(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subusers) != 1 || subusers[0].UUID != helper.UUID {
+		t.Errorf("unexpected subusers: %+v", subusers)
+	}
+	if _, err = client.GetServer(id); !errors.Is(err, alligator.ErrNotFound) {
+		t.Errorf("expected the removed subuser to lose access, got %v", err)
+	}
+
+	groups, err := owner.GetPermissions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for group, g := range groups {
+		for key := range g.Keys {
+			if n++; !alligator.Permission(group + "." + key).Valid() {
+				t.Errorf("%s.%s is missing a constant", group, key)
+			}
+		}
+	}
+	if n != 40 || groups["file"].Keys["read-content"] == "" {
+		t.Errorf("unexpected permission catalogue: %d permissions", n)
+	}
+}
@@ -0,0 +1,344 @@
+package alligator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var ErrConsoleDisconnected = errors.New("console is not connected")
+
+// consoleAuthTimeout bounds the wait for "auth success" after connecting.
+const consoleAuthTimeout = 10 * time.Second
+
+type ConsoleOptions struct {
+	Buffer int // Size of each event channel, 64 by default. Events are dropped while a channel is full
+	// Backoff between reconnection attempts. MaxAttempts is the number of consecutive failed attempts
+	// before the console gives up, 0 to retry until it's closed. 1 to 30 seconds by default
+	Reconnect *RetryPolicy
+}
+
+// ConsoleStats is the payload of the "stats" event.
+type ConsoleStats struct {
+	MemoryBytes      int64   `json:"memory_bytes"`
+	MemoryLimitBytes int64   `json:"memory_limit_bytes"`
+	CPUAbsolute      float64 `json:"cpu_absolute"`
+	DiskBytes        int64   `json:"disk_bytes"`
+	Network          struct {
+		RxBytes int64 `json:"rx_bytes"`
+		TxBytes int64 `json:"tx_bytes"`
+	} `json:"network"`
+	State  string `json:"state"`
+	Uptime int64  `json:"uptime"`
+}
+
+// Console is a live connection to a server's console on Wings. Events arrive on the channels,
+// which are closed once the console stops. The token is refreshed when Wings reports it's expiring
+// and the connection is reopened with backoff when it drops.
+type Console struct {
+	Output        <-chan string        // "console output" lines
+	Stats         <-chan *ConsoleStats // "stats", sent every few seconds while the server is running
+	Status        <-chan string        // "status": starting, running, stopping or offline
+	InstallOutput <-chan string        // "install output" lines
+	DaemonErrors  <-chan string        // "daemon error" messages
+	TokenEvents   <-chan string        // "token expiring" or "token expired", sent before the token is refreshed
+
+	output        chan string
+	stats         chan *ConsoleStats
+	status        chan string
+	installOutput chan string
+	daemonErrors  chan string
+	tokenEvents   chan string
+
+	client     *Client
+	identifier string
+	reconnect  *RetryPolicy
+	dialer     *websocket.Dialer
+	origin     string
+
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+
+	mu   sync.Mutex // Guards conn and serialises writes
+	conn *websocket.Conn
+}
+
+type consoleMessage struct {
+	Event string        `json:"event"`
+	Args  []interface{} `json:"args,omitempty"`
+}
+
+// OpenConsole connects to the console of a server and authenticates with a token from GetServerWebSocket.
+func (c *Client) OpenConsole(identifier string, opts ...ConsoleOptions) (*Console, error) {
+	return c.OpenConsoleCtx(context.Background(), identifier, opts...)
+}
+
+// OpenConsoleCtx is OpenConsole with a context, cancelling it closes the console.
+func (c *Client) OpenConsoleCtx(ctx context.Context, identifier string, opts ...ConsoleOptions) (*Console, error) {
+	var o ConsoleOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Buffer <= 0 {
+		o.Buffer = 64
+	}
+	if o.Reconnect == nil {
+		o.Reconnect = &RetryPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second, Jitter: 0.2}
+	}
+
+	con := &Console{
+		output:        make(chan string, o.Buffer),
+		stats:         make(chan *ConsoleStats, o.Buffer),
+		status:        make(chan string, o.Buffer),
+		installOutput: make(chan string, o.Buffer),
+		daemonErrors:  make(chan string, o.Buffer),
+		tokenEvents:   make(chan string, o.Buffer),
+		client:        c,
+		identifier:    identifier,
+		reconnect:     o.Reconnect,
+		dialer:        &websocket.Dialer{Proxy: http.ProxyFromEnvironment, HandshakeTimeout: 45 * time.Second},
+		parent:        ctx,
+		done:          make(chan struct{}),
+	}
+	con.Output, con.Stats, con.Status = con.output, con.stats, con.status
+	con.InstallOutput, con.DaemonErrors, con.TokenEvents = con.installOutput, con.daemonErrors, con.tokenEvents
+
+	// Wings only accepts connections coming from the panel
+	if u, err := url.Parse(c.PanelURL); err == nil {
+		con.origin = u.Scheme + "://" + u.Host
+	}
+	if t, ok := c.Http.Transport.(*http.Transport); ok {
+		con.dialer.Proxy, con.dialer.TLSClientConfig = t.Proxy, t.TLSClientConfig
+	}
+
+	conn, err := con.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	con.conn = conn
+	con.ctx, con.cancel = context.WithCancel(ctx)
+
+	go con.run(conn)
+	go func() {
+		<-con.ctx.Done()
+		con.mu.Lock()
+		if con.conn != nil {
+			con.conn.Close()
+		}
+		con.mu.Unlock()
+	}()
+
+	return con, nil
+}
+
+func (c *Console) connect(ctx context.Context) (*websocket.Conn, error) {
+	auth, err := c.client.GetServerWebSocketCtx(ctx, c.identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, auth.Socket, http.Header{"Origin": {c.origin}})
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.WriteJSON(consoleMessage{Event: "auth", Args: []interface{}{auth.Token}}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(consoleAuthTimeout))
+	for {
+		var msg consoleMessage
+		if err = conn.ReadJSON(&msg); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		switch msg.Event {
+		case "auth success":
+			conn.SetReadDeadline(time.Time{})
+			return conn, nil
+		case "jwt error", "daemon error":
+			conn.Close()
+			return nil, fmt.Errorf("console auth: %s", firstArg(msg))
+		}
+	}
+}
+
+func (c *Console) run(conn *websocket.Conn) {
+	defer func() {
+		close(c.output)
+		close(c.stats)
+		close(c.status)
+		close(c.installOutput)
+		close(c.daemonErrors)
+		close(c.tokenEvents)
+		close(c.done)
+	}()
+
+	for {
+		err := c.read(conn)
+
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+		conn.Close()
+
+		if c.ctx.Err() != nil {
+			c.err = c.parent.Err()
+			return
+		}
+
+		if conn, err = c.redial(err); err != nil {
+			if c.ctx.Err() != nil {
+				err = c.parent.Err()
+			}
+			c.err = err
+			return
+		}
+	}
+}
+
+// read dispatches events until the connection breaks.
+func (c *Console) read(conn *websocket.Conn) error {
+	for {
+		var msg consoleMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return err
+		}
+
+		switch msg.Event {
+		case "console output":
+			deliver(c.output, firstArg(msg))
+		case "install output":
+			deliver(c.installOutput, firstArg(msg))
+		case "status":
+			deliver(c.status, firstArg(msg))
+		case "daemon error":
+			deliver(c.daemonErrors, firstArg(msg))
+		case "stats":
+			var stats ConsoleStats
+			if json.Unmarshal([]byte(firstArg(msg)), &stats) == nil {
+				deliver(c.stats, &stats)
+			}
+		case "token expiring", "token expired":
+			deliver(c.tokenEvents, msg.Event)
+			auth, err := c.client.GetServerWebSocketCtx(c.ctx, c.identifier)
+			if err == nil {
+				err = c.send("auth", auth.Token)
+			}
+			if err != nil && msg.Event == "token expired" {
+				// Wings won't talk to us anymore, start over with a new connection
+				return err
+			}
+		}
+	}
+}
+
+func (c *Console) redial(cause error) (*websocket.Conn, error) {
+	for attempt := 1; ; attempt++ {
+		if err := sleep(c.ctx, c.reconnect.delay(attempt, nil)); err != nil {
+			return nil, err
+		}
+
+		conn, err := c.connect(c.ctx)
+		if err == nil {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.ctx.Err() != nil {
+				conn.Close()
+				return nil, c.ctx.Err()
+			}
+			c.conn = conn
+			return conn, nil
+		}
+
+		// The server is gone or the key lost access to it, retrying won't help
+		if errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		if c.reconnect.MaxAttempts > 0 && attempt >= c.reconnect.MaxAttempts {
+			return nil, fmt.Errorf("reconnect after %v: %w", cause, err)
+		}
+	}
+}
+
+func (c *Console) send(event string, args ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return ErrConsoleDisconnected
+	}
+	return c.conn.WriteJSON(consoleMessage{Event: event, Args: args})
+}
+
+// SendCommand runs a command in the server console.
+func (c *Console) SendCommand(command string) error {
+	return c.send("send command", command)
+}
+
+// SetPowerState sends a power signal: start, stop, restart or kill.
+func (c *Console) SetPowerState(signal string) error {
+	return c.send("set state", signal)
+}
+
+// RequestLogs asks Wings to replay the recent console output on Output.
+func (c *Console) RequestLogs() error {
+	return c.send("send logs")
+}
+
+// RequestStats asks Wings to send the current stats right away.
+func (c *Console) RequestStats() error {
+	return c.send("send stats")
+}
+
+// Done is closed once the console has stopped, after Close or when reconnecting gave up.
+func (c *Console) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the console stopped: nil after Close, the context error when its context was
+// cancelled, or the last error when reconnecting gave up. It's only set once Done is closed.
+func (c *Console) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Close disconnects the console and waits for its channels to be closed.
+func (c *Console) Close() error {
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+func firstArg(msg consoleMessage) string {
+	if len(msg.Args) == 0 {
+		return ""
+	}
+	if s, ok := msg.Args[0].(string); ok {
+		return s
+	}
+	buf, _ := json.Marshal(msg.Args[0])
+	return string(buf)
+}
+
+func deliver[T any](ch chan T, v T) {
+	select {
+	case ch <- v:
+	default:
+	}
+}
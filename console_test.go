@@ -0,0 +1,120 @@
+package alligator_test
+
+import (
+	"errors"
+	"github.com/m41denx/alligator"
+	"testing"
+	"time"
+)
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return v
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	panic("unreachable")
+}
+
+func TestConsole(t *testing.T) {
+	p := newPanel(t)
+
+	id := survival
+	p.SetPowerState(id, "running")
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(1))
+
+	con, err := client.OpenConsole(id, alligator.ConsoleOptions{
+		Reconnect: &alligator.RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer con.Close()
+
+	if state := receive(t, con.Status); state != "running" {
+		t.Errorf("expected running after auth, got %s", state)
+	}
+	p.ConsoleEvent(id, "console output", "Done (3.2s)! For help, type \"help\"")
+	if line := receive(t, con.Output); line != "Done (3.2s)! For help, type \"help\"" {
+		t.Errorf("unexpected output %q", line)
+	}
+	p.ConsoleEvent(id, "daemon error", "disk space exceeded")
+	if msg := receive(t, con.DaemonErrors); msg != "disk space exceeded" {
+		t.Errorf("unexpected daemon error %q", msg)
+	}
+
+	if err = con.SendCommand("say hi"); err != nil {
+		t.Fatal(err)
+	}
+	if err = con.SetPowerState("stop"); err != nil {
+		t.Fatal(err)
+	}
+	if state := receive(t, con.Status); state != "offline" {
+		t.Errorf("expected offline after stop, got %s", state)
+	}
+	if cmds := p.Commands(id); len(cmds) != 1 || cmds[0] != "say hi" {
+		t.Errorf("expected the command to reach the server, got %v", cmds)
+	}
+	if err = con.RequestStats(); err != nil {
+		t.Fatal(err)
+	}
+	if stats := receive(t, con.Stats); stats.State != "offline" || stats.MemoryBytes != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	// The token is refreshed without dropping the connection
+	p.ExpireConsoleTokens(id)
+	if event := receive(t, con.TokenEvents); event != "token expired" {
+		t.Errorf("unexpected token event %q", event)
+	}
+	if state := receive(t, con.Status); state != "offline" {
+		t.Errorf("expected the status again after re-auth, got %s", state)
+	}
+
+	// The connection is reopened after Wings goes away, and the logs can be replayed
+	p.DropConsoles(id)
+	if state := receive(t, con.Status); state != "offline" {
+		t.Errorf("expected the status after reconnecting, got %s", state)
+	}
+	if err = con.RequestLogs(); err != nil {
+		t.Fatal(err)
+	}
+	if line := receive(t, con.Output); line != "Done (3.2s)! For help, type \"help\"" {
+		t.Errorf("expected the replayed output, got %q", line)
+	}
+
+	if err = con.Close(); err != nil || con.Err() != nil {
+		t.Errorf("unexpected close errors %v %v", err, con.Err())
+	}
+	if _, ok := <-con.Output; ok {
+		t.Errorf("expected the channels to be closed")
+	}
+	if err = con.SendCommand("say bye"); !errors.Is(err, alligator.ErrConsoleDisconnected) {
+		t.Errorf("expected ErrConsoleDisconnected, got %v", err)
+	}
+
+	// Reconnecting gives up once the server is gone
+	con, err = client.OpenConsole(id, alligator.ConsoleOptions{
+		Reconnect: &alligator.RetryPolicy{BaseDelay: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	if err = app.DeleteServer(1, false); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-con.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("console kept reconnecting")
+	}
+	if !errors.Is(con.Err(), alligator.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", con.Err())
+	}
+}
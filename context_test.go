@@ -0,0 +1,46 @@
+package alligator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/download":
+			// Send part of the file, then stall until the caller gives up
+			w.Write(make([]byte, 1024))
+			w.(http.Flusher).Flush()
+			cancel()
+			<-r.Context().Done()
+		case r.URL.Query().Get("page") == "2":
+			cancel()
+			<-r.Context().Done()
+		default:
+			w.Write([]byte(`{"object":"list","data":[{"object":"location","attributes":{"id":1,"short":"eu"}}],` +
+				`"meta":{"pagination":{"total":2,"count":1,"per_page":1,"current_page":1,"total_pages":2,"links":{"next":"next"}}}}`))
+		}
+	}))
+	defer srv.Close()
+
+	app, _ := NewApp(srv.URL, "ptla_test")
+	if _, err := app.ListAllLocationsCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled while paging, got %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	client, _ := NewClient(srv.URL, "ptlc_test")
+	dl := &Downloader{client: client, Name: filepath.Join(t.TempDir(), "world.zip"), url: srv.URL + "/download"}
+	if err := dl.ExecuteCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled while downloading, got %v", err)
+	}
+}
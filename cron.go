@@ -0,0 +1,235 @@
+package alligator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNoCronRun = errors.New("cron expression never runs")
+
+// cronSearchYears bounds the search for the next run, so expressions like "0 0 31 2 *" fail instead of looping.
+const cronSearchYears = 5
+
+// ScheduleCron holds the five cron fields of a schedule, each in the usual crontab syntax:
+// "*", lists ("1,15"), ranges ("1-5"), steps ("*/15", "0-30/10") and names ("jan", "mon").
+// Day of week goes from 0 (Sunday) to 7 (Sunday again).
+type ScheduleCron struct {
+	DayOfWeek  string `json:"day_of_week"`
+	DayOfMonth string `json:"day_of_month"`
+	Month      string `json:"month"`
+	Hour       string `json:"hour"`
+	Minute     string `json:"minute"`
+}
+
+// ParseCron splits a crontab expression such as "*/30 4 * * 1-5" into its fields.
+func ParseCron(expr string) (ScheduleCron, error) {
+	f := strings.Fields(expr)
+	if len(f) != 5 {
+		return ScheduleCron{}, fmt.Errorf("cron expression %q: expected 5 fields, got %d", expr, len(f))
+	}
+	c := ScheduleCron{Minute: f[0], Hour: f[1], DayOfMonth: f[2], Month: f[3], DayOfWeek: f[4]}
+	if _, err := c.compile(); err != nil {
+		return ScheduleCron{}, err
+	}
+	return c, nil
+}
+
+// String returns the fields in crontab order: minute, hour, day of month, month and day of week.
+func (c ScheduleCron) String() string {
+	return strings.Join([]string{c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek}, " ")
+}
+
+// Validate reports the first field that can't be parsed.
+func (c ScheduleCron) Validate() error {
+	_, err := c.compile()
+	return err
+}
+
+// Next returns the first run strictly after t, in t's location.
+func (c ScheduleCron) Next(t time.Time) (time.Time, error) {
+	cs, err := c.compile()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cs.next(t)
+}
+
+// NextRuns returns the next n runs after t, in t's location. The panel evaluates schedules in its
+// own timezone, pass t in that location to get matching times. n <= 0 returns no runs.
+func (c ScheduleCron) NextRuns(t time.Time, n int) ([]time.Time, error) {
+	cs, err := c.compile()
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return []time.Time{}, nil
+	}
+
+	runs := make([]time.Time, 0, n)
+	for len(runs) < n {
+		if t, err = cs.next(t); err != nil {
+			return runs, err
+		}
+		runs = append(runs, t)
+	}
+	return runs, nil
+}
+
+// cronSchedule has a bit set for every value a field matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+var (
+	cronMonths   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	cronWeekdays = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+func (c ScheduleCron) compile() (*cronSchedule, error) {
+	var cs cronSchedule
+	var err error
+	for _, f := range []struct {
+		name     string
+		value    string
+		min, max int
+		names    map[string]int
+		dst      *uint64
+	}{
+		{"minute", c.Minute, 0, 59, nil, &cs.minute},
+		{"hour", c.Hour, 0, 23, nil, &cs.hour},
+		{"day of month", c.DayOfMonth, 1, 31, nil, &cs.dom},
+		{"month", c.Month, 1, 12, cronMonths, &cs.month},
+		{"day of week", c.DayOfWeek, 0, 7, cronWeekdays, &cs.dow},
+	} {
+		if *f.dst, err = parseCronField(f.value, f.min, f.max, f.names); err != nil {
+			return nil, fmt.Errorf("cron %s %q: %w", f.name, f.value, err)
+		}
+	}
+
+	// 7 is Sunday too
+	if cs.dow&(1<<7) != 0 {
+		cs.dow |= 1
+	}
+	cs.anyDom = isWildcard(c.DayOfMonth)
+	cs.anyDow = isWildcard(c.DayOfWeek)
+	return &cs, nil
+}
+
+// isWildcard follows Vixie cron: a day field starting with "*", like "*/2", doesn't restrict the day.
+func isWildcard(field string) bool {
+	field = strings.TrimSpace(field)
+	return strings.HasPrefix(field, "*") || field == "?"
+}
+
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field == "" {
+		return 0, errors.New("empty field")
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		lo, hi := min, max
+		switch {
+		case expr == "*" || expr == "?":
+		case strings.Contains(expr, "-"):
+			from, to, _ := strings.Cut(expr, "-")
+			var err error
+			if lo, err = cronValue(from, min, max, names); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(to, min, max, names); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", expr)
+			}
+		default:
+			v, err := cronValue(expr, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[s]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+func (cs *cronSchedule) next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		var skip time.Time
+		switch {
+		case cs.month&(1<<uint(t.Month())) == 0:
+			skip = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !cs.matchDay(t):
+			skip = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case cs.hour&(1<<uint(t.Hour())) == 0:
+			skip = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case cs.minute&(1<<uint(t.Minute())) == 0:
+			skip = t.Add(time.Minute)
+		default:
+			return t, nil
+		}
+
+		// Wall clock times repeat when DST ends, always move forward
+		if !skip.After(t) {
+			skip = t.Add(time.Minute)
+		}
+		t = skip
+	}
+	return time.Time{}, ErrNoCronRun
+}
+
+// matchDay follows crontab: when both day fields are restricted, either of them matching is enough.
+func (cs *cronSchedule) matchDay(t time.Time) bool {
+	dom := cs.dom&(1<<uint(t.Day())) != 0
+	dow := cs.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case cs.anyDom && cs.anyDow:
+		return true
+	case cs.anyDom:
+		return dow
+	case cs.anyDow:
+		return dom
+	default:
+		return dom || dow
+	}
+}
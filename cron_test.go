@@ -0,0 +1,69 @@
+package alligator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	c, err := ParseCron("*/30 4 * jan-mar mon-fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Minute != "*/30" || c.Hour != "4" || c.DayOfMonth != "*" || c.Month != "jan-mar" || c.DayOfWeek != "mon-fri" {
+		t.Errorf("unexpected fields: %+v", c)
+	}
+	if c.String() != "*/30 4 * jan-mar mon-fri" {
+		t.Errorf("unexpected string: %q", c.String())
+	}
+
+	for _, bad := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err = ParseCron(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	from := time.Date(2024, 2, 27, 23, 40, 10, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"*/15 * * * *", []string{"2024-02-27 23:45", "2024-02-28 00:00", "2024-02-28 00:15"}},
+		{"0 4 * * sun", []string{"2024-03-03 04:00", "2024-03-10 04:00", "2024-03-17 04:00"}},
+		{"0 0 * * 7", []string{"2024-03-03 00:00", "2024-03-10 00:00", "2024-03-17 00:00"}},
+		// Both day fields restricted: the 1st of the month or any Friday
+		{"0 12 1 * fri", []string{"2024-03-01 12:00", "2024-03-08 12:00", "2024-03-15 12:00"}},
+		{"30 6 29 2 *", []string{"2024-02-29 06:30", "2028-02-29 06:30"}},
+		// A stepped wildcard still counts as unrestricted: only Mondays
+		{"0 0 */2 * mon", []string{"2024-03-04 00:00", "2024-03-11 00:00", "2024-03-18 00:00"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+		runs, err := c.NextRuns(from, len(tt.want))
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		for i, run := range runs {
+			if got := run.Format("2006-01-02 15:04"); got != tt.want[i] {
+				t.Errorf("%s: run %d is %s, expected %s", tt.expr, i, got, tt.want[i])
+			}
+		}
+	}
+
+	c, _ := ParseCron("0 0 * * *")
+	if runs, err := c.NextRuns(from, -1); err != nil || len(runs) != 0 {
+		t.Errorf("expected no runs for a negative count, got %v %v", runs, err)
+	}
+
+	c, _ = ParseCron("0 0 31 2 *")
+	if _, err := c.Next(from); !errors.Is(err, ErrNoCronRun) {
+		t.Errorf("expected ErrNoCronRun for February 31st, got %v", err)
+	}
+}
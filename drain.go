@@ -0,0 +1,203 @@
+package alligator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m41denx/alligator/options"
+	"sync"
+	"time"
+)
+
+type DrainOptions struct {
+	Message     string        // Console command announcing the shutdown, e.g. "say Restarting for maintenance". Empty to skip
+	Notice      time.Duration // How long to wait between the announcement and the stop signal, no wait by default
+	Grace       time.Duration // How long each server gets to stop, 30 seconds by default
+	Interval    time.Duration // How often the server state is checked while waiting, 1 second by default
+	Concurrency int           // How many servers are drained at once, 10 by default
+}
+
+type DrainResult struct {
+	Server     *AppServer
+	WasRunning bool  // The server wasn't offline when the drain started
+	Stopped    bool  // The server is offline, either already or within the grace period
+	Skipped    bool  // The drain was cancelled before the server was reached
+	Err        error // Error hit while announcing, stopping or polling the server
+}
+
+// DrainReport is returned by DrainNode and keeps what UndrainNode needs to restore the node.
+type DrainReport struct {
+	Node             int
+	WasInMaintenance bool
+	Servers          []*DrainResult
+}
+
+// Stopped returns the servers that are offline.
+func (r *DrainReport) Stopped() []*AppServer {
+	var servers []*AppServer
+	for _, s := range r.Servers {
+		if s.Stopped {
+			servers = append(servers, s.Server)
+		}
+	}
+	return servers
+}
+
+// Remaining returns the servers that didn't stop within the grace period.
+func (r *DrainReport) Remaining() []*AppServer {
+	var servers []*AppServer
+	for _, s := range r.Servers {
+		if !s.Stopped {
+			servers = append(servers, s.Server)
+		}
+	}
+	return servers
+}
+
+// DrainNode puts a node in maintenance mode and stops every server on it. Running servers get
+// opts.Message sent to their console, then a stop signal, and are given opts.Grace to go offline.
+// client must have access to all servers on the node, so it usually belongs to a root admin.
+// When ctx is cancelled, the report is returned along with ctx.Err().
+func DrainNode(app ApplicationAPI, client ClientAPI, node int, opts DrainOptions) (*DrainReport, error) {
+	return DrainNodeCtx(context.Background(), app, client, node, opts)
+}
+
+func DrainNodeCtx(ctx context.Context, app ApplicationAPI, client ClientAPI, node int, opts DrainOptions) (*DrainReport, error) {
+	if opts.Grace <= 0 {
+		opts.Grace = 30 * time.Second
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 10
+	}
+
+	n, err := app.GetNodeCtx(ctx, node, options.GetNodeOptions{Include: options.IncludeNodes{Servers: true}})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DrainReport{Node: node, WasInMaintenance: n.MaintenanceMode}
+	if !n.MaintenanceMode {
+		if err = setMaintenance(ctx, app, n, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// Servers are stopped in parallel so the grace period doesn't add up
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, opts.Concurrency)
+	for _, s := range n.Servers {
+		result := &DrainResult{Server: s}
+		report.Servers = append(report.Servers, result)
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err = ctx.Err(); err != nil {
+			result.Skipped, result.Err = true, err
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			drainServer(ctx, client, result, opts)
+		}()
+	}
+	wg.Wait()
+
+	return report, ctx.Err()
+}
+
+func drainServer(ctx context.Context, client ClientAPI, r *DrainResult, opts DrainOptions) {
+	id := r.Server.Identifier
+	res, err := client.GetServerResourcesCtx(ctx, id)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	if res.State == "offline" {
+		r.Stopped = true
+		return
+	}
+	r.WasRunning = true
+
+	if opts.Message != "" {
+		// The server is stopped even if it couldn't be warned
+		if err = client.SendServerCommandCtx(ctx, id, opts.Message); err != nil {
+			r.Err = fmt.Errorf("announce: %w", err)
+		} else if opts.Notice > 0 {
+			select {
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+				return
+			case <-time.After(opts.Notice):
+			}
+		}
+	}
+	if err = client.SetServerPowerStateCtx(ctx, id, "stop"); err != nil {
+		r.Err = fmt.Errorf("stop: %w", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Grace)
+	defer cancel()
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if res, err = client.GetServerResourcesCtx(ctx, id); err == nil && res.State == "offline" {
+			r.Stopped = true
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// UndrainNode reverts DrainNode: it starts the servers that were running and takes the node out
+// of maintenance mode, unless it was already in maintenance before the drain.
+func UndrainNode(app ApplicationAPI, client ClientAPI, report *DrainReport) error {
+	return UndrainNodeCtx(context.Background(), app, client, report)
+}
+
+func UndrainNodeCtx(ctx context.Context, app ApplicationAPI, client ClientAPI, report *DrainReport) error {
+	var errs []error
+	if !report.WasInMaintenance {
+		n, err := app.GetNodeCtx(ctx, report.Node)
+		if err == nil {
+			err = setMaintenance(ctx, app, n, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range report.Servers {
+		if !s.WasRunning || !s.Stopped {
+			continue
+		}
+		if err := client.SetServerPowerStateCtx(ctx, s.Server.Identifier, "start"); err != nil {
+			errs = append(errs, fmt.Errorf("start %s: %w", s.Server.Identifier, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setMaintenance(ctx context.Context, app ApplicationAPI, n *Node, enabled bool) error {
+	fields := n.UpdateDescriptor()
+	fields.MaintenanceMode = Ptr(enabled)
+	_, err := app.UpdateNodeCtx(ctx, n.ID, *fields)
+	return err
+}
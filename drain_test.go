@@ -0,0 +1,104 @@
+package alligator_test
+
+import (
+	"context"
+	"errors"
+	"github.com/m41denx/alligator"
+	"testing"
+	"time"
+)
+
+func TestDrainNode(t *testing.T) {
+	p := newPanel(t)
+
+	idle := p.AddServer(&alligator.AppServer{ID: 2, Name: "idle", UserID: 1, Allocation: 2, NestID: 1, EggID: 1})
+	p.SetPowerState(survival, "running")
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(2))
+
+	report, err := alligator.DrainNode(app, client, 1, alligator.DrainOptions{
+		Message:  "say Restarting for maintenance",
+		Grace:    time.Second,
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Stopped()) != 2 || len(report.Remaining()) != 0 {
+		t.Errorf("expected both servers stopped, got %+v", report.Servers)
+	}
+	if cmds := p.Commands(survival); len(cmds) != 1 || cmds[0] != "say Restarting for maintenance" {
+		t.Errorf("expected the announcement on survival, got %v", cmds)
+	}
+	if len(p.Commands(idle.Identifier)) != 0 {
+		t.Errorf("offline servers shouldn't be announced to")
+	}
+	if p.PowerState(survival) != "offline" {
+		t.Errorf("expected survival offline, got %s", p.PowerState(survival))
+	}
+	node, err := app.GetNode(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !node.MaintenanceMode {
+		t.Errorf("expected the node in maintenance")
+	}
+
+	if err = alligator.UndrainNode(app, client, report); err != nil {
+		t.Fatal(err)
+	}
+	if p.PowerState(survival) != "running" || p.PowerState(idle.Identifier) != "offline" {
+		t.Errorf("expected only survival to be restarted: %s %s", p.PowerState(survival), p.PowerState(idle.Identifier))
+	}
+	if node, err = app.GetNode(1); err != nil {
+		t.Fatal(err)
+	}
+	if node.MaintenanceMode {
+		t.Errorf("expected maintenance mode to be lifted")
+	}
+}
+
+func TestDrainNodeConcurrency(t *testing.T) {
+	p := newPanel(t)
+
+	other := p.AddServer(&alligator.AppServer{ID: 2, Name: "creative", UserID: 1, Allocation: 2, NestID: 1, EggID: 1})
+	p.SetPowerState(survival, "running")
+	p.SetPowerState(other.Identifier, "running")
+
+	app, _ := alligator.NewApp(p.URL, p.AppKey)
+	client, _ := alligator.NewClient(p.URL, p.ClientKey(2))
+
+	start := time.Now()
+	report, err := alligator.DrainNode(app, client, 1, alligator.DrainOptions{
+		Message:     "say Restarting in 50ms",
+		Notice:      50 * time.Millisecond,
+		Grace:       time.Second,
+		Interval:    10 * time.Millisecond,
+		Concurrency: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Stopped()) != 2 {
+		t.Errorf("expected both servers stopped, got %+v", report.Servers)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected the servers to be drained one after another, took %s", elapsed)
+	}
+
+	p.SetPowerState(survival, "running")
+	p.SetPowerState(other.Identifier, "running")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	report, err = alligator.DrainNodeCtx(ctx, app, client, 1, alligator.DrainOptions{Message: "say bye", Notice: time.Minute, Concurrency: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the cancellation to be returned, got %v", err)
+	}
+	if r := report.Servers[0]; r.Stopped || r.Skipped || !errors.Is(r.Err, context.DeadlineExceeded) || p.PowerState(survival) != "running" {
+		t.Errorf("expected the stop to be cancelled during the notice: %+v", r)
+	}
+	if r := report.Servers[1]; !r.Skipped || p.PowerState(other.Identifier) != "running" {
+		t.Errorf("expected the second server to be skipped: %+v", r)
+	}
+}
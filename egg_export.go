@@ -0,0 +1,375 @@
+package alligator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	EggExportV1 = "PTDL_v1"
+	EggExportV2 = "PTDL_v2"
+)
+
+var ErrUnsupportedEggExport = errors.New("unsupported egg export version")
+
+// eggExport is the egg JSON the panel exports. Config values are JSON documents stored as strings.
+type eggExport struct {
+	Comment string `json:"_comment,omitempty"`
+	Meta    struct {
+		Version   string  `json:"version"`
+		UpdateURL *string `json:"update_url"`
+	} `json:"meta"`
+	ExportedAt   string    `json:"exported_at"`
+	Name         string    `json:"name"`
+	Author       string    `json:"author"`
+	Description  *string   `json:"description"`
+	Features     []string  `json:"features"`
+	DockerImages eggImages `json:"docker_images"`
+	Images       []string  `json:"images,omitempty"` // PTDL_v1
+	FileDenylist []string  `json:"file_denylist"`
+	Startup      string    `json:"startup"`
+	Config       struct {
+		Files   string `json:"files"`
+		Startup string `json:"startup"`
+		Logs    string `json:"logs"`
+		Stop    string `json:"stop"`
+	} `json:"config"`
+	Scripts struct {
+		Installation struct {
+			Script     string `json:"script"`
+			Container  string `json:"container"`
+			Entrypoint string `json:"entrypoint"`
+		} `json:"installation"`
+	} `json:"scripts"`
+	Variables []*eggExportVariable `json:"variables"`
+}
+
+type eggExportVariable struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	EnvVariable  string     `json:"env_variable"`
+	DefaultValue string     `json:"default_value"`
+	UserViewable exportBool `json:"user_viewable"`
+	UserEditable exportBool `json:"user_editable"`
+	Rules        string     `json:"rules"`
+	FieldType    string     `json:"field_type"`
+}
+
+// exportBool accepts the 0/1 integers found in older exports.
+type exportBool bool
+
+func (b *exportBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+func boolToInt(b exportBool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// eggImages keeps the default image first, the panel picks the first entry of docker_images.
+type eggImages struct {
+	Default string
+	Images  map[string]string
+}
+
+func (i *eggImages) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &i.Images); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+	if _, err := dec.Token(); err == nil {
+		dec.Decode(&i.Default)
+	}
+	return nil
+}
+
+func (i eggImages) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, len(i.Images))
+	for name := range i.Images {
+		names = append(names, name)
+	}
+	// Names of the default image go first, every group is sorted by name
+	sort.Slice(names, func(a, b int) bool {
+		defaultA, defaultB := i.Images[names[a]] == i.Default, i.Images[names[b]] == i.Default
+		if defaultA != defaultB {
+			return defaultA
+		}
+		return names[a] < names[b]
+	})
+
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for n, name := range names {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, _ := json.Marshal(i.Images[name])
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// ParseEggExport reads an egg exported by the panel (PTDL_v1 or PTDL_v2) into an Egg with its Variables.
+func ParseEggExport(r io.Reader) (*Egg, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var export eggExport
+	if err = json.Unmarshal(buf, &export); err != nil {
+		return nil, err
+	}
+	if export.Meta.Version != EggExportV1 && export.Meta.Version != EggExportV2 {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedEggExport, export.Meta.Version)
+	}
+
+	egg := &Egg{
+		Name:         export.Name,
+		Author:       export.Author,
+		Features:     export.Features,
+		DockerImage:  export.DockerImages.Default,
+		DockerImages: export.DockerImages.Images,
+		Startup:      export.Startup,
+	}
+	if export.Description != nil {
+		egg.Description = *export.Description
+	}
+	if export.Meta.UpdateURL != nil {
+		egg.UpdateURL = *export.Meta.UpdateURL
+	}
+
+	if len(export.Images) > 0 {
+		egg.DockerImage = export.Images[0]
+		egg.DockerImages = make(map[string]string, len(export.Images))
+		for _, image := range export.Images {
+			egg.DockerImages[image] = image
+		}
+	}
+
+	egg.Config.Stop = export.Config.Stop
+	egg.Config.FileDenylist = export.FileDenylist
+	for name, field := range map[string]struct {
+		value string
+		dst   interface{}
+	}{
+		"files":   {export.Config.Files, &egg.Config.Files},
+		"startup": {export.Config.Startup, &egg.Config.Startup},
+		"logs":    {export.Config.Logs, &egg.Config.Logs},
+	} {
+		// PHP encodes an empty object as an empty array
+		if v := strings.TrimSpace(field.value); v != "" && v != "[]" {
+			if err = json.Unmarshal([]byte(v), field.dst); err != nil {
+				return nil, fmt.Errorf("config.%s: %w", name, err)
+			}
+		}
+	}
+
+	egg.Script.Install = export.Scripts.Installation.Script
+	egg.Script.Container = export.Scripts.Installation.Container
+	egg.Script.Entry = export.Scripts.Installation.Entrypoint
+
+	egg.Variables = make([]*EggVariable, 0, len(export.Variables))
+	for _, v := range export.Variables {
+		egg.Variables = append(egg.Variables, &EggVariable{
+			Name:         v.Name,
+			Description:  v.Description,
+			EnvVariable:  v.EnvVariable,
+			DefaultValue: v.DefaultValue,
+			UserViewable: boolToInt(v.UserViewable),
+			UserEditable: boolToInt(v.UserEditable),
+			Rules:        v.Rules,
+		})
+	}
+
+	return egg, nil
+}
+
+// Export encodes the egg in the panel's PTDL_v2 export format, ready to be imported by the panel.
+func (e *Egg) Export() ([]byte, error) {
+	export := eggExport{
+		Comment:      "DO NOT EDIT: FILE GENERATED AUTOMATICALLY BY PTERODACTYL PANEL - PTERODACTYL.IO",
+		ExportedAt:   time.Now().Format(time.RFC3339),
+		Name:         e.Name,
+		Author:       e.Author,
+		Description:  &e.Description,
+		Features:     e.Features,
+		DockerImages: eggImages{Default: e.DockerImage, Images: e.DockerImages},
+		FileDenylist: e.Config.FileDenylist,
+		Startup:      e.Startup,
+	}
+	export.Meta.Version = EggExportV2
+	if e.UpdateURL != "" {
+		export.Meta.UpdateURL = &e.UpdateURL
+	}
+	if len(e.DockerImages) == 0 && e.DockerImage != "" {
+		export.DockerImages.Images = map[string]string{e.DockerImage: e.DockerImage}
+	}
+	if export.FileDenylist == nil {
+		export.FileDenylist = []string{}
+	}
+
+	files := e.Config.Files
+	if files == nil {
+		files = map[string]EggFileConfig{}
+	}
+	for dst, v := range map[*string]interface{}{
+		&export.Config.Files:   files,
+		&export.Config.Startup: e.Config.Startup,
+		&export.Config.Logs:    e.Config.Logs,
+	} {
+		buf, err := json.MarshalIndent(v, "", "    ")
+		if err != nil {
+			return nil, err
+		}
+		*dst = string(buf)
+	}
+	export.Config.Stop = e.Config.Stop
+
+	export.Scripts.Installation.Script = e.Script.Install
+	export.Scripts.Installation.Container = e.Script.Container
+	export.Scripts.Installation.Entrypoint = e.Script.Entry
+
+	export.Variables = make([]*eggExportVariable, 0, len(e.Variables))
+	for _, v := range e.Variables {
+		export.Variables = append(export.Variables, &eggExportVariable{
+			Name:         v.Name,
+			Description:  v.Description,
+			EnvVariable:  v.EnvVariable,
+			DefaultValue: v.DefaultValue,
+			UserViewable: v.UserViewable != 0,
+			UserEditable: v.UserEditable != 0,
+			Rules:        v.Rules,
+			FieldType:    "text",
+		})
+	}
+
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(export); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type EggDrift struct {
+	Field  string      // e.g. "startup", "config.stop" or "variables.SERVER_JARFILE.default_value"
+	Local  interface{} // nil when the remote egg has something the local one doesn't
+	Remote interface{} // nil when the local egg has something the remote one doesn't
+}
+
+func (d EggDrift) String() string {
+	return fmt.Sprintf("%s: local %v, remote %v", d.Field, d.Local, d.Remote)
+}
+
+// CompareEggs reports the differences between a local egg, usually from ParseEggExport, and one
+// fetched with GetEgg. Fetch it with IncludeEggs.Variables, otherwise every variable is reported.
+// Fields the API doesn't return, like Features and UpdateURL, are not compared.
+func CompareEggs(local, remote *Egg) []EggDrift {
+	var drift []EggDrift
+	diff := func(field string, l, r interface{}) {
+		if !reflect.DeepEqual(l, r) {
+			drift = append(drift, EggDrift{Field: field, Local: l, Remote: r})
+		}
+	}
+
+	diff("name", local.Name, remote.Name)
+	diff("author", local.Author, remote.Author)
+	diff("description", local.Description, remote.Description)
+	diff("startup", local.Startup, remote.Startup)
+	diff("docker_image", local.DockerImage, remote.DockerImage)
+	diff("docker_images", nonNilImages(local.DockerImages), nonNilImages(remote.DockerImages))
+	diff("config.files", normalize(local.Config.Files), normalize(remote.Config.Files))
+	diff("config.startup", normalize(local.Config.Startup), normalize(remote.Config.Startup))
+	diff("config.logs", local.Config.Logs, remote.Config.Logs)
+	diff("config.stop", local.Config.Stop, remote.Config.Stop)
+	diff("config.file_denylist", nonNilStrings(local.Config.FileDenylist), nonNilStrings(remote.Config.FileDenylist))
+	diff("script.install", normalizeNewlines(local.Script.Install), normalizeNewlines(remote.Script.Install))
+	diff("script.container", local.Script.Container, remote.Script.Container)
+	diff("script.entry", local.Script.Entry, remote.Script.Entry)
+
+	remoteVars := make(map[string]*EggVariable, len(remote.Variables))
+	for _, v := range remote.Variables {
+		remoteVars[v.EnvVariable] = v
+	}
+	for _, l := range local.Variables {
+		r, ok := remoteVars[l.EnvVariable]
+		if !ok {
+			drift = append(drift, EggDrift{Field: "variables." + l.EnvVariable, Local: l})
+			continue
+		}
+		delete(remoteVars, l.EnvVariable)
+
+		prefix := "variables." + l.EnvVariable + "."
+		diff(prefix+"name", l.Name, r.Name)
+		diff(prefix+"description", l.Description, r.Description)
+		diff(prefix+"default_value", l.DefaultValue, r.DefaultValue)
+		diff(prefix+"user_viewable", l.UserViewable, r.UserViewable)
+		diff(prefix+"user_editable", l.UserEditable, r.UserEditable)
+		diff(prefix+"rules", l.Rules, r.Rules)
+	}
+
+	extra := make([]string, 0, len(remoteVars))
+	for env := range remoteVars {
+		extra = append(extra, env)
+	}
+	sort.Strings(extra)
+	for _, env := range extra {
+		drift = append(drift, EggDrift{Field: "variables." + env, Remote: remoteVars[env]})
+	}
+
+	return drift
+}
+
+// normalize round-trips v through JSON so numbers and empty values compare the same on both sides.
+func normalize(v interface{}) interface{} {
+	buf, _ := json.Marshal(v)
+	var out interface{}
+	json.Unmarshal(buf, &out)
+	return out
+}
+
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+func nonNilImages(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package alligator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseEggExport(t *testing.T) {
+	f, err := os.Open("testdata/egg-paper.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	egg, err := ParseEggExport(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if egg.Name != "Paper" || egg.DockerImage != "ghcr.io/pterodactyl/yolks:java_21" || len(egg.DockerImages) != 3 {
+		t.Errorf("unexpected egg: %+v", egg)
+	}
+	if len(egg.Features) != 3 || egg.Config.Stop != "stop" || egg.Config.Startup.Done != ")! For help, type " {
+		t.Errorf("unexpected features or config: %v %+v", egg.Features, egg.Config)
+	}
+	props, ok := egg.Config.Files["server.properties"]
+	if !ok || props.Parser != "properties" || props.Find["server-ip"] != "0.0.0.0" {
+		t.Errorf("config files were not decoded: %+v", egg.Config.Files)
+	}
+	if egg.Script.Entry != "ash" || !strings.HasPrefix(egg.Script.Install, "#!/bin/ash") {
+		t.Errorf("unexpected script: %+v", egg.Script)
+	}
+	if len(egg.Variables) != 2 || egg.Variables[1].UserViewable != 1 || egg.Variables[1].UserEditable != 0 {
+		t.Errorf("unexpected variables: %+v", egg.Variables)
+	}
+
+	// Exporting and parsing again gives back the same egg
+	buf, err := egg.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseEggExport(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift := CompareEggs(egg, again); len(drift) > 0 {
+		t.Errorf("export didn't round-trip: %v", drift)
+	}
+
+	again.Startup = "java -jar server.jar"
+	again.Variables = again.Variables[:1]
+	drift := CompareEggs(egg, again)
+	if len(drift) != 2 || drift[0].Field != "startup" || drift[1].Field != "variables.MINECRAFT_VERSION" || drift[1].Remote != nil {
+		t.Errorf("unexpected drift: %v", drift)
+	}
+}
+
+func TestEggImagesOrder(t *testing.T) {
+	images := eggImages{Default: "yolks:java_17", Images: map[string]string{
+		"Java 8":  "yolks:java_8",
+		"Java 21": "yolks:java_21",
+		"Java 17": "yolks:java_17",
+		"Latest":  "yolks:java_17",
+	}}
+	want := `{"Java 17":"yolks:java_17","Latest":"yolks:java_17","Java 21":"yolks:java_21","Java 8":"yolks:java_8"}`
+	for n := 0; n < 10; n++ {
+		if buf, err := json.Marshal(images); err != nil || string(buf) != want {
+			t.Fatalf("expected %s, got %s %v", want, buf, err)
+		}
+	}
+
+	var decoded eggImages
+	if err := json.Unmarshal([]byte(want), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Default != "yolks:java_17" || len(decoded.Images) != 4 {
+		t.Errorf("unexpected images: %+v", decoded)
+	}
+}
+
+func TestEggFileConfig(t *testing.T) {
+	data := `{"parser":"yaml","find":{"listeners[0].host":{"0.0.0.0":"{{server.build.default.ip}}"},"listeners[0].port":"{{server.build.default.port}}"}}`
+	var c EggFileConfig
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Parser != "yaml" || c.Find["listeners[0].port"] != "{{server.build.default.port}}" || c.Match["listeners[0].host"]["0.0.0.0"] != "{{server.build.default.ip}}" {
+		t.Errorf("unexpected file config: %+v", c)
+	}
+	if buf, err := json.Marshal(c); err != nil || string(buf) != data {
+		t.Errorf("file config didn't round-trip: %s %v", buf, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"find":{"port":25565}}`), &c); err == nil {
+		t.Error("expected an error for a number in find")
+	}
+}
+
+func TestParseEggExportVersions(t *testing.T) {
+	v1 := `{"meta":{"version":"PTDL_v1"},"name":"Old","images":["quay.io/pterodactyl/core:java"],` +
+		`"config":{"files":"[]","startup":"{\"done\":\"Done\",\"userInteraction\":[]}","logs":"{\"custom\":false}","stop":"stop"},` +
+		`"variables":[{"env_variable":"VERSION","user_viewable":1,"user_editable":0}]}`
+	egg, err := ParseEggExport(strings.NewReader(v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if egg.DockerImage != "quay.io/pterodactyl/core:java" || egg.DockerImages[egg.DockerImage] != egg.DockerImage {
+		t.Errorf("v1 images were not converted: %q %v", egg.DockerImage, egg.DockerImages)
+	}
+	if len(egg.Variables) != 1 || egg.Variables[0].UserViewable != 1 || egg.Variables[0].UserEditable != 0 {
+		t.Errorf("unexpected variables: %+v", egg.Variables[0])
+	}
+
+	_, err = ParseEggExport(strings.NewReader(`{"meta":{"version":"PTDL_v3"}}`))
+	if !errors.Is(err, ErrUnsupportedEggExport) {
+		t.Errorf("expected ErrUnsupportedEggExport, got %v", err)
+	}
+}
@@ -1,6 +1,21 @@
 package alligator
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by ApiError through errors.Is
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limited")
+)
 
 type Error struct {
 	Code   string      `json:"code"`
@@ -13,10 +28,104 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s (%s): %s", e.Status, e.Code, e.Detail)
 }
 
+// SourceField returns the request field that failed validation, if any.
+func (e *Error) SourceField() string {
+	return e.meta("source_field")
+}
+
+// Rule returns the validation rule that failed, if any.
+func (e *Error) Rule() string {
+	return e.meta("rule")
+}
+
+func (e *Error) meta(key string) string {
+	m, ok := e.Meta.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	v, _ := m[key].(string)
+	return v
+}
+
 type ApiError struct {
-	Errors []*Error `json:"errors"`
+	Errors     []*Error `json:"errors"`
+	StatusCode int      `json:"-"`
+	Method     string   `json:"-"`
+	Path       string   `json:"-"`
+	Body       []byte   `json:"-"` // Raw response body
 }
 
 func (e *ApiError) Error() string {
-	return fmt.Sprintf("%d unexpected error(s)", len(e.Errors))
+	var detail string
+	switch len(e.Errors) {
+	case 0:
+		detail = http.StatusText(e.StatusCode)
+	case 1:
+		detail = e.Errors[0].Detail
+	default:
+		detail = fmt.Sprintf("%s (and %d more error(s))", e.Errors[0].Detail, len(e.Errors)-1)
+	}
+
+	return fmt.Sprintf("%s %s: %d: %s", e.Method, e.Path, e.StatusCode, detail)
+}
+
+func (e *ApiError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
+type FieldError struct {
+	Rule   string
+	Detail string
+}
+
+// ValidationError is returned for 422 responses. Fields maps each rejected
+// request field (e.g. "limits.memory") to the rules it failed.
+type ValidationError struct {
+	*ApiError
+	Fields map[string][]FieldError
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.ApiError
+}
+
+func newApiError(res *http.Response, body []byte) error {
+	e := &ApiError{StatusCode: res.StatusCode, Body: body}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.Path = res.Request.URL.Path
+	}
+
+	// Reverse proxies answer with HTML error pages, those only keep the raw body
+	if err := json.Unmarshal(body, e); err != nil {
+		e.Errors = nil
+	}
+
+	if res.StatusCode != http.StatusUnprocessableEntity {
+		return e
+	}
+
+	verr := &ValidationError{ApiError: e, Fields: make(map[string][]FieldError)}
+	for _, fe := range e.Errors {
+		if field := fe.SourceField(); field != "" {
+			verr.Fields[field] = append(verr.Fields[field], FieldError{Rule: fe.Rule(), Detail: fe.Detail})
+		}
+	}
+
+	return verr
 }
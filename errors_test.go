@@ -0,0 +1,52 @@
+package alligator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestApiErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/application/users/1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":"NotFoundHttpException","status":"404","detail":"The requested resource could not be found on the server."}]}`))
+		case "/api/application/users":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"errors":[` +
+				`{"code":"ValidationException","status":"422","detail":"The email field is required.","meta":{"source_field":"email","rule":"required"}},` +
+				`{"code":"ValidationException","status":"422","detail":"The username must be a string.","meta":{"source_field":"username","rule":"string"}}]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+		}
+	}))
+	defer srv.Close()
+
+	app, _ := NewApp(srv.URL, "ptla_test")
+
+	_, err := app.GetUser(1)
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.Method != "GET" || apiErr.Path != "/api/application/users/1" {
+		t.Errorf("expected request details on the error, got %#v", err)
+	}
+
+	_, err = app.CreateUser(CreateUserDescriptor{})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if len(verr.Fields["email"]) != 1 || verr.Fields["email"][0].Rule != "required" || verr.Fields["username"][0].Rule != "string" {
+		t.Errorf("unexpected fields: %#v", verr.Fields)
+	}
+
+	_, err = app.ListNodes()
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || len(apiErr.Body) == 0 {
+		t.Errorf("expected the HTML body to be kept, got %#v", err)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/m41denx/alligator
 
 go 1.22
+
+require github.com/gorilla/websocket v1.5.3
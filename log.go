@@ -0,0 +1,156 @@
+package alligator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// maxLoggedBody bounds how much of a response is buffered for debug logs.
+const maxLoggedBody = 64 << 10
+
+// Fields that are never written to debug body dumps, e.g. NodeConfiguration.Token,
+// database passwords, TwoFactorData.Secret and CreateUserDescriptor.Password.
+var secretFields = map[string]bool{
+	"token":                 true,
+	"secret":                true,
+	"secret_token":          true,
+	"image_url_data":        true, // otpauth url embedding the 2FA secret
+	"password":              true,
+	"password_confirmation": true,
+	"current_password":      true,
+}
+
+func (s *sender) logRequest(req *http.Request) {
+	ctx := req.Context()
+	if !s.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+		"query", query(req.URL),
+		"headers", redactHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			buf, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, "body", redactBody(buf))
+		}
+	}
+
+	s.logger.DebugContext(ctx, "alligator request", attrs...)
+}
+
+func (s *sender) logResponse(req *http.Request, res *http.Response, err error, latency time.Duration, attempt int) {
+	ctx := req.Context()
+	attrs := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+		"query", query(req.URL),
+		"latency", latency,
+		"attempt", attempt,
+	}
+
+	if err != nil {
+		s.logger.WarnContext(ctx, "alligator request failed", append(attrs, "error", err)...)
+		return
+	}
+
+	attrs = append(attrs,
+		"status", res.StatusCode,
+		"ratelimit_limit", res.Header.Get("X-RateLimit-Limit"),
+		"ratelimit_remaining", res.Header.Get("X-RateLimit-Remaining"),
+	)
+
+	if s.logger.Enabled(ctx, slog.LevelDebug) && res.Body != nil {
+		attrs = append(attrs, "body", logBody(res))
+	}
+
+	level := slog.LevelInfo
+	if res.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	s.logger.Log(ctx, level, "alligator response", attrs...)
+}
+
+// logBody returns the redacted JSON body of a response. Downloads and other non-JSON bodies are left
+// unread, and only the first maxLoggedBody bytes are buffered, the caller still reads the whole body.
+func logBody(res *http.Response) string {
+	contentType := res.Header.Get("Content-Type")
+	if !strings.Contains(contentType, "json") {
+		return "<" + contentType + ", " + strconv.FormatInt(res.ContentLength, 10) + " bytes>"
+	}
+
+	buf, _ := io.ReadAll(io.LimitReader(res.Body, maxLoggedBody+1))
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), res.Body), res.Body}
+
+	if len(buf) > maxLoggedBody {
+		return "<" + contentType + ", more than " + strconv.Itoa(maxLoggedBody) + " bytes>"
+	}
+	return redactBody(buf)
+}
+
+func query(u *url.URL) string {
+	q, err := url.QueryUnescape(u.RawQuery)
+	if err != nil {
+		return u.RawQuery
+	}
+	return q
+}
+
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer "+redacted)
+	}
+	return out
+}
+
+// redactBody masks secret fields of a JSON body. Anything that isn't JSON is only reported by size.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "<" + http.DetectContentType(body) + ", " + strconv.Itoa(len(body)) + " bytes>"
+	}
+
+	out, _ := json.Marshal(redactValue(v))
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if secretFields[k] {
+				if val != nil && val != "" {
+					v[k] = redacted
+				}
+				continue
+			}
+			v[k] = redactValue(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+	}
+	return v
+}
@@ -0,0 +1,72 @@
+package alligator
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogRedaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "240")
+		w.Header().Set("X-RateLimit-Remaining", "239")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/client/servers/abc/files/contents":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(bytes.Repeat([]byte("x"), 1<<20))
+		case "/api/application/users/1":
+			// Bigger than what gets logged, the caller must still get all of it
+			w.Write([]byte(`{"object":"user","attributes":{"id":1,"username":"` + strings.Repeat("a", maxLoggedBody) + `"}}`))
+		case "/api/application/nodes/1/configuration":
+			w.Write([]byte(`{"debug":false,"uuid":"abc","token_id":"tid","token":"node-secret-token"}`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"object":"user","attributes":{"id":1,"username":"example"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	app, _ := NewApp(srv.URL, "ptla_supersecretkey", WithLogger(logger))
+
+	cfg, err := app.GetNodeConfiguration(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Token != "node-secret-token" {
+		t.Errorf("logging must not alter the response, got token %q", cfg.Token)
+	}
+
+	if _, err = app.CreateUser(CreateUserDescriptor{Username: "example", Password: "hunter2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := app.GetUser(1)
+	if err != nil || len(user.Username) != maxLoggedBody {
+		t.Errorf("expected the whole body to be decoded: %v", err)
+	}
+	client, _ := NewClient(srv.URL, "ptlc_test", WithLogger(logger))
+	if data, err := client.GetServerFileContents("abc", "world.zip"); err != nil || len(data) != 1<<20 {
+		t.Errorf("expected the file to be complete: %v %d", err, len(data))
+	}
+
+	logs := out.String()
+	for _, secret := range []string{"ptla_supersecretkey", "node-secret-token", "hunter2"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("secret %q leaked into logs:\n%s", secret, logs)
+		}
+	}
+	if strings.Contains(logs, "xxxx") || strings.Contains(logs, "aaaa") {
+		t.Error("expected large and non-JSON bodies to be left out of the logs")
+	}
+	for _, expected := range []string{`"status":201`, `"ratelimit_remaining":"239"`, `"path":"/api/application/users"`, `\"username\":\"example\"`} {
+		if !strings.Contains(logs, expected) {
+			t.Errorf("expected %s in logs:\n%s", expected, logs)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package alligator
+
+import "net/http"
+
+// Doer sends a single HTTP request, http.Client.Do being the innermost one.
+type Doer func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a Doer to inspect or rewrite requests and responses.
+// It runs for every attempt of a panel API call and for Downloader and Uploader transfers.
+type Middleware func(next Doer) Doer
+
+// chain wraps do with mws, the first middleware being the outermost one.
+func chain(do Doer, mws []Middleware) Doer {
+	for i := len(mws) - 1; i >= 0; i-- {
+		do = mws[i](do)
+	}
+	return do
+}
+
+func (a *Application) Use(mws ...Middleware) {
+	a.Middleware = append(a.Middleware, mws...)
+}
+
+func (c *Client) Use(mws ...Middleware) {
+	c.Middleware = append(c.Middleware, mws...)
+}
+
+func WithMiddleware(mws ...Middleware) Option {
+	return func(s *settings) error {
+		s.middleware = append(s.middleware, mws...)
+		return nil
+	}
+}
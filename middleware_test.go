@@ -0,0 +1,51 @@
+package alligator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.Path+" "+r.Header.Get("X-Correlation-ID"))
+		if r.URL.Path == "/signed" {
+			w.Write([]byte("file contents"))
+			return
+		}
+		w.Write([]byte(`{"attributes":{"id":1}}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Set("X-Correlation-ID", "abc")
+				return next(req)
+			}
+		}
+	}
+
+	client, _ := NewClient(srv.URL, "ptlc_key", WithMiddleware(tag("outer")))
+	client.Use(tag("inner"))
+
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+
+	dl := &Downloader{client: client, Name: filepath.Join(t.TempDir(), "file.txt"), url: srv.URL + "/signed"}
+	if err := dl.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(order) != 4 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("unexpected middleware order: %v", order)
+	}
+	if len(seen) != 2 || seen[0] != "/api/client/account abc" || seen[1] != "/signed abc" {
+		t.Errorf("middleware was not applied to every request: %v", seen)
+	}
+}
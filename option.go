@@ -0,0 +1,159 @@
+package alligator
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	applicationKeyPrefix = "ptla_"
+	clientKeyPrefix      = "ptlc_"
+)
+
+// Option configures an Application or Client on construction.
+type Option func(*settings) error
+
+type settings struct {
+	http       *http.Client
+	timeout    time.Duration
+	userAgent  string
+	retry      *RetryPolicy
+	limiter    *RateLimiter
+	limiterSet bool
+	logger     *slog.Logger
+	headers    http.Header
+	middleware []Middleware
+}
+
+func newSettings(key string, opts []Option) (*settings, error) {
+	s := &settings{}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.http == nil {
+		s.http = &http.Client{}
+	}
+	if s.timeout > 0 {
+		// Don't touch a client that may be shared with the caller
+		h := *s.http
+		h.Timeout = s.timeout
+		s.http = &h
+	}
+	if !s.limiterSet {
+		s.limiter = SharedRateLimiter(key)
+	}
+
+	return s, nil
+}
+
+func WithHTTPClient(h *http.Client) Option {
+	return func(s *settings) error {
+		if h == nil {
+			return errors.New("http client cannot be nil")
+		}
+		s.http = h
+		return nil
+	}
+}
+
+func WithTimeout(d time.Duration) Option {
+	return func(s *settings) error {
+		if d < 0 {
+			return errors.New("timeout cannot be negative")
+		}
+		s.timeout = d
+		return nil
+	}
+}
+
+func WithUserAgent(ua string) Option {
+	return func(s *settings) error {
+		s.userAgent = ua
+		return nil
+	}
+}
+
+func WithRetry(p *RetryPolicy) Option {
+	return func(s *settings) error {
+		s.retry = p
+		return nil
+	}
+}
+
+// WithRateLimiter replaces the limiter shared by every instance using the same key. Pass nil to disable rate limiting.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(s *settings) error {
+		s.limiter = l
+		s.limiterSet = true
+		return nil
+	}
+}
+
+func WithLogger(l *slog.Logger) Option {
+	return func(s *settings) error {
+		s.logger = l
+		return nil
+	}
+}
+
+// WithBaseHeaders adds headers to every request. They can't override the authorization or content headers.
+func WithBaseHeaders(h http.Header) Option {
+	return func(s *settings) error {
+		if s.headers == nil {
+			s.headers = make(http.Header, len(h))
+		}
+		for k, v := range h {
+			s.headers[k] = append(s.headers[k], v...)
+		}
+		return nil
+	}
+}
+
+func setBaseHeaders(req *http.Request, headers http.Header, ua string) {
+	for k, v := range headers {
+		req.Header[k] = append([]string(nil), v...)
+	}
+
+	if ua == "" {
+		ua = "Alligator v" + Version
+	}
+	req.Header.Set("User-Agent", ua)
+}
+
+// normalizeURL checks the panel url and strips trailing slashes and api paths,
+// keeping any sub-path the panel is installed under.
+func normalizeURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("invalid panel url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("panel url must use http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", errors.New("panel url is missing a host")
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	for _, suffix := range []string{"/api/application", "/api/client", "/api"} {
+		if strings.HasSuffix(path, suffix) {
+			path = strings.TrimSuffix(path, suffix)
+			break
+		}
+	}
+
+	u.Path = path
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String(), nil
+}
@@ -0,0 +1,57 @@
+package alligator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewAppOptions(t *testing.T) {
+	for in, expected := range map[string]string{
+		"https://panel.example.com":                  "https://panel.example.com",
+		"https://panel.example.com///":               "https://panel.example.com",
+		"https://example.com/panel/":                 "https://example.com/panel",
+		"https://example.com/panel/api/application/": "https://example.com/panel",
+	} {
+		app, err := NewApp(in, "ptla_key")
+		if err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		if app.PanelURL != expected {
+			t.Errorf("%s: expected %s, got %s", in, expected, app.PanelURL)
+		}
+	}
+
+	for _, in := range []string{"panel.example.com", "ftp://panel.example.com", "https://"} {
+		if _, err := NewApp(in, "ptla_key"); err == nil {
+			t.Errorf("%s: expected an invalid url error", in)
+		}
+	}
+
+	if _, err := NewApp("https://panel.example.com", "ptlc_key"); err == nil {
+		t.Error("expected client keys to be rejected by NewApp")
+	}
+	if _, err := NewClient("https://panel.example.com", "ptla_key"); err == nil {
+		t.Error("expected application keys to be rejected by NewClient")
+	}
+
+	shared := &http.Client{}
+	app, err := NewApp("https://panel.example.com", "ptla_key",
+		WithHTTPClient(shared),
+		WithTimeout(5*time.Second),
+		WithUserAgent("test-agent"),
+		WithBaseHeaders(http.Header{"X-Tenant": {"acme"}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Http.Timeout != 5*time.Second || shared.Timeout != 0 {
+		t.Error("expected the timeout to be set on a copy of the http client")
+	}
+
+	req := app.newRequest(context.Background(), "GET", "/servers", nil)
+	if req.Header.Get("User-Agent") != "test-agent" || req.Header.Get("X-Tenant") != "acme" {
+		t.Errorf("unexpected headers: %v", req.Header)
+	}
+}
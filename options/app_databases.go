@@ -0,0 +1,24 @@
+package options
+
+type IncludeDatabases struct {
+	Password bool `param:"password"` // Password of the database user
+	Host     bool `param:"host"`     // Information about the database host
+}
+
+type ListDatabasesOptions struct {
+	Include IncludeDatabases
+}
+
+func (o *ListDatabasesOptions) getOptions() *requestOptions {
+	return &requestOptions{
+		Include: o.Include,
+	}
+}
+
+type GetDatabaseOptions ListDatabasesOptions
+
+func (o *GetDatabaseOptions) getOptions() *requestOptions {
+	return &requestOptions{
+		Include: o.Include,
+	}
+}
@@ -1,18 +1,41 @@
 package options
 
+const (
+	ListLocationsSort_ID_DESC = "-id"
+	ListLocationsSort_ID_ASC  = "id"
+)
+
+var listLocationsSorts = []string{"id"}
+
 type IncludeLocations struct {
 	Nodes   bool `param:"nodes"`   // List of nodes assigned to the location
 	Servers bool `param:"servers"` // List of servers in the location
 }
 
+type FiltersLocations struct {
+	Short string `param:"short,omitempty"` // Short code, e.g. "eu"
+	Long  string `param:"long,omitempty"`  // Description
+}
+
 type ListLocationsOptions struct {
 	requestOptions
 	Include IncludeLocations
+	Filters FiltersLocations
+	SortBy  string // -id | id
+	Page    int    // Page to fetch, starting at 1
+	PerPage int    // Number of results per page, 50 by default
 }
 
 func (o *ListLocationsOptions) getOptions() *requestOptions {
 	return &requestOptions{
 		Include: o.Include,
+		Filters: o.Filters,
+		SortBy:  o.SortBy,
+		Sorts:   listLocationsSorts,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
 
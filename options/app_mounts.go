@@ -0,0 +1,50 @@
+package options
+
+const (
+	ListMountsSort_ID_DESC   = "-id"
+	ListMountsSort_ID_ASC    = "id"
+	ListMountsSort_UUID_DESC = "-uuid"
+	ListMountsSort_UUID_ASC  = "uuid"
+)
+
+var listMountsSorts = []string{"id", "uuid"}
+
+type IncludeMounts struct {
+	Eggs    bool `param:"eggs"`    // List of eggs the mount is available to
+	Nodes   bool `param:"nodes"`   // List of nodes the mount is available on
+	Servers bool `param:"servers"` // List of servers using the mount
+}
+
+type FiltersMounts struct {
+	UUID string `param:"uuid,omitempty"`
+	Name string `param:"name,omitempty"`
+}
+
+type ListMountsOptions struct {
+	Include IncludeMounts
+	Filters FiltersMounts
+	SortBy  string // -id | id | -uuid | uuid
+	Page    int    // Page to fetch, starting at 1
+	PerPage int    // Number of results per page, 50 by default
+}
+
+func (o *ListMountsOptions) getOptions() *requestOptions {
+	return &requestOptions{
+		Include: o.Include,
+		Filters: o.Filters,
+		SortBy:  o.SortBy,
+		Sorts:   listMountsSorts,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
+	}
+}
+
+type GetMountOptions ListMountsOptions
+
+func (o *GetMountOptions) getOptions() *requestOptions {
+	return &requestOptions{
+		Include: o.Include,
+	}
+}
@@ -8,11 +8,17 @@ type IncludeNests struct {
 type ListNestsOptions struct {
 	requestOptions
 	Include IncludeNests
+	Page    int // Page to fetch, starting at 1
+	PerPage int // Number of results per page, 50 by default
 }
 
 func (o *ListNestsOptions) getOptions() *requestOptions {
 	return &requestOptions{
 		Include: o.Include,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
 
@@ -33,11 +39,17 @@ type IncludeEggs struct {
 type ListEggsOptions struct {
 	requestOptions
 	Include IncludeEggs
+	Page    int // Page to fetch, starting at 1
+	PerPage int // Number of results per page, 50 by default
 }
 
 func (o *ListEggsOptions) getOptions() *requestOptions {
 	return &requestOptions{
 		Include: o.Include,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
 
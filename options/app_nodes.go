@@ -1,19 +1,50 @@
 package options
 
+const (
+	ListNodesSort_ID_DESC     = "-id"
+	ListNodesSort_ID_ASC      = "id"
+	ListNodesSort_UUID_DESC   = "-uuid"
+	ListNodesSort_UUID_ASC    = "uuid"
+	ListNodesSort_Memory_DESC = "-memory"
+	ListNodesSort_Memory_ASC  = "memory"
+	ListNodesSort_Disk_DESC   = "-disk"
+	ListNodesSort_Disk_ASC    = "disk"
+)
+
+var listNodesSorts = []string{"id", "uuid", "memory", "disk"}
+
 type IncludeNodes struct {
 	Allocations bool `param:"allocations"` // List of allocations added to the node
 	Location    bool `param:"location"`    // Information about the location the node is assigned to
 	Servers     bool `param:"servers"`     // List of servers on the node
 }
 
+type FiltersNodes struct {
+	UUID          string `param:"uuid,omitempty"`
+	Name          string `param:"name,omitempty"`
+	FQDN          string `param:"fqdn,omitempty"`
+	DaemonTokenId string `param:"daemon_token_id,omitempty"`
+}
+
 type ListNodesOptions struct {
 	requestOptions
 	Include IncludeNodes
+	Filters FiltersNodes
+	SortBy  string // -id | id | -uuid | uuid | -memory | memory | -disk | disk
+	Page    int    // Page to fetch, starting at 1
+	PerPage int    // Number of results per page, 50 by default
 }
 
 func (o *ListNodesOptions) getOptions() *requestOptions {
 	return &requestOptions{
 		Include: o.Include,
+		Filters: o.Filters,
+		SortBy:  o.SortBy,
+		Sorts:   listNodesSorts,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
 
@@ -30,13 +61,28 @@ type IncludeAllocations struct {
 	Server bool `param:"server"` // Information about the server the allocation belongs to
 }
 
+type FiltersAllocations struct {
+	IP       string `param:"ip,omitempty"`
+	Port     int    `param:"port,omitempty"`
+	IPAlias  string `param:"ip_alias,omitempty"`
+	ServerID *int   `param:"server_id"` // ID of the server the allocation is assigned to, 0 for unassigned ones
+}
+
 type ListNodeAllocationsOptions struct {
 	requestOptions
 	Include IncludeAllocations
+	Filters FiltersAllocations
+	Page    int // Page to fetch, starting at 1
+	PerPage int // Number of results per page, 50 by default
 }
 
 func (o *ListNodeAllocationsOptions) getOptions() *requestOptions {
 	return &requestOptions{
 		Include: o.Include,
+		Filters: o.Filters,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
@@ -1,5 +1,14 @@
 package options
 
+const (
+	ListServersSort_ID_DESC   = "-id"
+	ListServersSort_ID_ASC    = "id"
+	ListServersSort_UUID_DESC = "-uuid"
+	ListServersSort_UUID_ASC  = "uuid"
+)
+
+var listServersSorts = []string{"id", "uuid"}
+
 type IncludeServers struct {
 	Allocations bool `param:"allocations"` // List of allocations assigned to the server
 	User        bool `param:"user"`        // Information about the server owner
@@ -13,13 +22,33 @@ type IncludeServers struct {
 	Databases   bool `param:"databases"`   // List of databases on the server
 }
 
+type FiltersServers struct {
+	Name        string `param:"name,omitempty"`
+	Description string `param:"description,omitempty"`
+	UUID        string `param:"uuid,omitempty"`
+	UUIDShort   string `param:"uuidShort,omitempty"` // The 8 character identifier
+	ExternalId  string `param:"external_id,omitempty"`
+	Image       string `param:"image,omitempty"` // Docker image
+}
+
 type ListServersOptions struct {
 	Include IncludeServers
+	Filters FiltersServers
+	SortBy  string // -id | id | -uuid | uuid
+	Page    int    // Page to fetch, starting at 1
+	PerPage int    // Number of results per page, 50 by default
 }
 
 func (o *ListServersOptions) getOptions() *requestOptions {
 	return &requestOptions{
 		Include: o.Include,
+		Filters: o.Filters,
+		SortBy:  o.SortBy,
+		Sorts:   listServersSorts,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
 
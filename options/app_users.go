@@ -7,15 +7,17 @@ const (
 	ListUsersSort_UUID_ASC  = "uuid"
 )
 
+var listUsersSorts = []string{"id", "uuid"}
+
 type IncludeUsers struct {
 	Servers bool `param:"servers"` // List of servers the user has access to
 }
 
 type FiltersUsers struct {
-	Email      string `param:"email"`
-	UUID       string `param:"uuid"`
-	Username   string `param:"username"`
-	ExternalId string `param:"external_id"`
+	Email      string `param:"email,omitempty"`
+	UUID       string `param:"uuid,omitempty"`
+	Username   string `param:"username,omitempty"`
+	ExternalId string `param:"external_id,omitempty"`
 }
 
 type ListUsersOptions struct {
@@ -23,6 +25,8 @@ type ListUsersOptions struct {
 	Include IncludeUsers
 	Filters FiltersUsers
 	SortBy  string // -id | id | -uuid | uuid
+	Page    int    // Page to fetch, starting at 1
+	PerPage int    // Number of results per page, 50 by default
 }
 
 func (o *ListUsersOptions) getOptions() *requestOptions {
@@ -30,6 +34,11 @@ func (o *ListUsersOptions) getOptions() *requestOptions {
 		Include: o.Include,
 		Filters: o.Filters,
 		SortBy:  o.SortBy,
+		Sorts:   listUsersSorts,
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
 	}
 }
 
@@ -0,0 +1,16 @@
+package options
+
+type ListBackupsOptions struct {
+	requestOptions
+	Page    int // Page to fetch, starting at 1
+	PerPage int // Number of results per page, 20 by default and at most 50
+}
+
+func (o *ListBackupsOptions) getOptions() *requestOptions {
+	return &requestOptions{
+		Parameters: pageParameters{
+			Page:    o.Page,
+			PerPage: o.PerPage,
+		},
+	}
+}
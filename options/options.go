@@ -1,23 +1,64 @@
 package options
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// ErrUnknownSort is returned by ValidateRequestOptions when SortBy isn't supported by the endpoint
+var ErrUnknownSort = errors.New("unknown sort key")
+
 type requestOptions struct {
 	Include    interface{}
 	Filters    interface{}
 	Parameters interface{}
 	SortBy     string
+	Sorts      []string // Keys SortBy may use, with or without the leading "-"
+}
+
+type pageParameters struct {
+	Page    int `param:"page,omitempty"`
+	PerPage int `param:"per_page,omitempty"`
 }
 
 type options interface {
 	getOptions() *requestOptions
 }
 
+// ValidateRequestOptions checks the options before they are sent, so a typo in SortBy
+// fails early instead of being forwarded to the panel.
+func ValidateRequestOptions(opts options) error {
+	o := opts.getOptions()
+	if o.SortBy == "" {
+		return nil
+	}
+
+	key := strings.TrimPrefix(o.SortBy, "-")
+	for _, s := range o.Sorts {
+		if s == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q, expected one of: %s", ErrUnknownSort, o.SortBy, strings.Join(o.Sorts, ", "))
+}
+
+// ParseRequestOptions encodes the options into a query string. Filters and parameters are named by
+// their param tag. The only tag options are omitempty, which skips zero values, and repeat, which sends
+// one key per slice element instead of joining them with commas; other options are ignored:
+//
+//	Port     int       `param:"port,omitempty"`  // Not sent when 0
+//	ServerID *int      `param:"server_id"`       // Not sent when nil, sent when it points to 0
+//	Tags     []string  `param:"tags"`            // Comma-joined: tags=a,b
+//	IDs      []int     `param:"ids[],repeat"`    // Repeated keys: ids[]=1&ids[]=2
+//	Since    time.Time `param:"since,omitempty"` // RFC 3339
+//
+// Fields implementing encoding.TextMarshaler are encoded with MarshalText.
 func ParseRequestOptions(opts options) string {
 	o := opts.getOptions()
 	vals := url.Values{}
@@ -30,16 +71,13 @@ func ParseRequestOptions(opts options) string {
 			for i := 0; i < t.NumField(); i++ {
 				ft := t.Field(i)
 				fv := v.Field(i)
-				if fv.Interface() == reflect.Zero(ft.Type).Interface() {
-					// Skip zero values
-					continue
-				}
 				if fv.Kind() != reflect.Bool {
 					// Skip non-bools
 					continue
 				}
 				if fv.Bool() {
-					vals.Add("include", ft.Tag.Get("param"))
+					name, _ := parseTag(ft.Tag.Get("param"))
+					vals.Add("include", name)
 				}
 			}
 		}
@@ -49,22 +87,7 @@ func ParseRequestOptions(opts options) string {
 	}
 
 	// Filters
-	{
-		t := reflect.TypeOf(o.Filters)
-		v := reflect.ValueOf(o.Filters)
-		if t != nil && t.Kind() == reflect.Struct {
-			for i := 0; i < t.NumField(); i++ {
-				ft := t.Field(i)
-				fv := v.Field(i)
-				if fv.Interface() == reflect.Zero(ft.Type).Interface() {
-					// Skip zero values
-					continue
-				}
-				// If we declare fields ourselves then it's 100% string. In any other case it won't panic anyway
-				vals.Set(fmt.Sprintf("filter[%s]", ft.Tag.Get("param")), fv.String())
-			}
-		}
-	}
+	encodeFields(vals, o.Filters, "filter[%s]")
 
 	//Sort
 	if o.SortBy != "" {
@@ -72,21 +95,101 @@ func ParseRequestOptions(opts options) string {
 	}
 
 	// Other parameters
-	{
-		t := reflect.TypeOf(o.Parameters)
-		v := reflect.ValueOf(o.Parameters)
-		if t != nil && t.Kind() == reflect.Struct {
-			for i := 0; i < t.NumField(); i++ {
-				ft := t.Field(i)
-				fv := v.Field(i)
-				if fv.Interface() == reflect.Zero(ft.Type).Interface() {
-					// Skip zero values
-					continue
-				}
-				vals.Set(ft.Tag.Get("param"), fv.String())
+	encodeFields(vals, o.Parameters, "%s")
+
+	return vals.Encode()
+}
+
+type tagOptions struct {
+	omitEmpty bool
+	repeat    bool
+}
+
+func parseTag(tag string) (string, tagOptions) {
+	name, rest, _ := strings.Cut(tag, ",")
+	var opts tagOptions
+	for _, opt := range strings.Split(rest, ",") {
+		switch opt {
+		case "omitempty":
+			opts.omitEmpty = true
+		case "repeat":
+			opts.repeat = true
+		}
+	}
+	return name, opts
+}
+
+// encodeFields adds the tagged fields of a struct to vals, with keys formatted by key.
+func encodeFields(vals url.Values, fields interface{}, key string) {
+	t := reflect.TypeOf(fields)
+	v := reflect.ValueOf(fields)
+	if t == nil || t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		fv := v.Field(i)
+		name, opts := parseTag(ft.Tag.Get("param"))
+		if name == "" || !ft.IsExported() {
+			continue
+		}
+		if opts.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
 			}
+			fv = fv.Elem()
 		}
+
+		k := fmt.Sprintf(key, name)
+		if (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array) && !isText(fv) {
+			items := make([]string, 0, fv.Len())
+			for j := 0; j < fv.Len(); j++ {
+				items = append(items, formatValue(fv.Index(j)))
+			}
+			if opts.repeat {
+				vals[k] = items
+			} else {
+				vals.Set(k, strings.Join(items, ","))
+			}
+			continue
+		}
+		vals.Set(k, formatValue(fv))
 	}
+}
 
-	return vals.Encode()
+func isText(v reflect.Value) bool {
+	_, ok := v.Interface().(encoding.TextMarshaler)
+	return ok
+}
+
+func formatValue(v reflect.Value) string {
+	switch val := v.Interface().(type) {
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case encoding.TextMarshaler:
+		text, _ := val.MarshalText()
+		return string(text)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return formatValue(v.Elem())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
 }
@@ -1,7 +1,9 @@
 package options
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestOptions(t *testing.T) {
@@ -19,3 +21,85 @@ func TestOptions(t *testing.T) {
 		t.Errorf("expected:\n\t%s,\ngot:\n\t%s", expected, ParseRequestOptions(&userOpts))
 	}
 }
+
+func TestPageOptions(t *testing.T) {
+	serverOpts := ListServersOptions{
+		Include: IncludeServers{Allocations: true},
+		Page:    3,
+		PerPage: 100,
+	}
+
+	expected := "include=allocations&page=3&per_page=100"
+	if ParseRequestOptions(&serverOpts) != expected {
+		t.Errorf("expected:\n\t%s,\ngot:\n\t%s", expected, ParseRequestOptions(&serverOpts))
+	}
+}
+
+func TestFilterOptions(t *testing.T) {
+	serverOpts := ListServersOptions{
+		Filters: FiltersServers{UUIDShort: "1a2b3c4d", Image: "java"},
+		SortBy:  ListServersSort_UUID_DESC,
+	}
+
+	expected := "filter%5Bimage%5D=java&filter%5BuuidShort%5D=1a2b3c4d&sort=-uuid"
+	if ParseRequestOptions(&serverOpts) != expected {
+		t.Errorf("expected:\n\t%s,\ngot:\n\t%s", expected, ParseRequestOptions(&serverOpts))
+	}
+}
+
+func TestValidateRequestOptions(t *testing.T) {
+	for _, sort := range []string{"", ListNodesSort_Memory_DESC, ListNodesSort_Disk_ASC} {
+		if err := ValidateRequestOptions(&ListNodesOptions{SortBy: sort}); err != nil {
+			t.Errorf("%q: unexpected error: %v", sort, err)
+		}
+	}
+
+	err := ValidateRequestOptions(&ListLocationsOptions{SortBy: "-short"})
+	if !errors.Is(err, ErrUnknownSort) {
+		t.Errorf("expected ErrUnknownSort, got %v", err)
+	}
+}
+
+type encodingFilters struct {
+	Zero     int       `param:"zero"`
+	Omitted  int       `param:"omitted,omitempty"`
+	Pointer  *int      `param:"pointer"`
+	Nil      *int      `param:"nil"`
+	Bool     bool      `param:"bool,omitempty"`
+	Since    time.Time `param:"since,omitempty"`
+	Tags     []string  `param:"tags,omitempty"`
+	Repeated []int     `param:"ids[],repeat"`
+	Level    testLevel `param:"level"`
+}
+
+type testLevel int
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+type encodingOptions struct {
+	Filters encodingFilters
+}
+
+func (o *encodingOptions) getOptions() *requestOptions {
+	return &requestOptions{Filters: o.Filters}
+}
+
+func TestParamEncoding(t *testing.T) {
+	zero := 0
+	opts := encodingOptions{Filters: encodingFilters{
+		Pointer:  &zero,
+		Bool:     true,
+		Since:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Tags:     []string{"a", "b"},
+		Repeated: []int{1, 2},
+		Level:    1,
+	}}
+
+	expected := "filter%5Bbool%5D=true&filter%5Bids%5B%5D%5D=1&filter%5Bids%5B%5D%5D=2&filter%5Blevel%5D=high&" +
+		"filter%5Bpointer%5D=0&filter%5Bsince%5D=2024-05-01T12%3A00%3A00Z&filter%5Btags%5D=a%2Cb&filter%5Bzero%5D=0"
+	if ParseRequestOptions(&opts) != expected {
+		t.Errorf("expected:\n\t%s,\ngot:\n\t%s", expected, ParseRequestOptions(&opts))
+	}
+}
@@ -0,0 +1,185 @@
+package alligator
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+type Pagination struct {
+	Total       int             `json:"total"`
+	Count       int             `json:"count"`
+	PerPage     int             `json:"per_page"`
+	CurrentPage int             `json:"current_page"`
+	TotalPages  int             `json:"total_pages"`
+	Links       PaginationLinks `json:"links"`
+}
+
+type PaginationLinks struct {
+	Previous string `json:"previous,omitempty"`
+	Next     string `json:"next,omitempty"`
+}
+
+func (l *PaginationLinks) UnmarshalJSON(b []byte) error {
+	// PHP encodes an empty links object as an empty array
+	if string(b) == "[]" {
+		*l = PaginationLinks{}
+		return nil
+	}
+
+	var links struct {
+		Previous string `json:"previous,omitempty"`
+		Next     string `json:"next,omitempty"`
+	}
+	if err := json.Unmarshal(b, &links); err != nil {
+		return err
+	}
+
+	l.Previous = links.Previous
+	l.Next = links.Next
+
+	return nil
+}
+
+type pageFetcher[T any] func(ctx context.Context, page int) ([]T, *Pagination, error)
+
+// Pager walks the pages of a paginated list endpoint.
+type Pager[T any] struct {
+	// Concurrency is the number of pages All fetches in parallel once the
+	// total page count is known. Values below 2 fetch pages one by one.
+	Concurrency int
+
+	fetch      pageFetcher[T]
+	page       int
+	pagination *Pagination
+	done       bool
+}
+
+func newPager[T any](start int, fetch pageFetcher[T]) *Pager[T] {
+	if start < 1 {
+		start = 1
+	}
+	return &Pager[T]{fetch: fetch, page: start}
+}
+
+// Pagination returns the pagination block of the last fetched page.
+func (p *Pager[T]) Pagination() *Pagination {
+	return p.pagination
+}
+
+func (p *Pager[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page. It returns an empty slice once every page has been read.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return []T{}, nil
+	}
+
+	items, pg, err := p.fetch(ctx, p.page)
+	if err != nil {
+		return nil, err
+	}
+
+	p.pagination = pg
+	p.page++
+	p.done = len(items) == 0 || pg == nil || (pg.Links.Next == "" && pg.CurrentPage >= pg.TotalPages)
+
+	return items, nil
+}
+
+// All fetches every remaining page and returns their items in order.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	all, err := p.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Concurrency > 1 && !p.done {
+		rest, err := p.prefetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rest...)
+	}
+
+	for !p.done {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+
+	return all, nil
+}
+
+func (p *Pager[T]) prefetch(ctx context.Context) ([]T, error) {
+	first, last := p.page, p.pagination.TotalPages
+	if last < first {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		fail    error
+		results = make([][]T, last-first+1)
+		pages   = make(chan int)
+	)
+
+	workers := p.Concurrency
+	if workers > len(results) {
+		workers = len(results)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				items, _, err := p.fetch(ctx, page)
+				if err != nil {
+					once.Do(func() {
+						fail = err
+						cancel()
+					})
+					return
+				}
+				results[page-first] = items
+			}
+		}()
+	}
+
+feed:
+	for page := first; page <= last; page++ {
+		select {
+		case pages <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	if fail != nil {
+		return nil, fail
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	all := make([]T, 0)
+	for _, items := range results {
+		all = append(all, items...)
+	}
+
+	p.page = last + 1
+	p.done = true
+
+	return all, nil
+}
@@ -0,0 +1,68 @@
+package alligator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func fakePages(total, perPage int, calls *int32) pageFetcher[int] {
+	return func(ctx context.Context, page int) ([]int, *Pagination, error) {
+		atomic.AddInt32(calls, 1)
+		pages := (total + perPage - 1) / perPage
+		items := make([]int, 0, perPage)
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			items = append(items, i)
+		}
+		pg := &Pagination{Total: total, Count: len(items), PerPage: perPage, CurrentPage: page, TotalPages: pages}
+		if page < pages {
+			pg.Links.Next = "next"
+		}
+		return items, pg, nil
+	}
+}
+
+func TestPagerAll(t *testing.T) {
+	for _, concurrency := range []int{0, 4} {
+		var calls int32
+		p := newPager(0, fakePages(123, 10, &calls))
+		p.Concurrency = concurrency
+
+		all, err := p.All(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 123 {
+			t.Fatalf("concurrency %d: expected 123 items, got %d", concurrency, len(all))
+		}
+		for i, v := range all {
+			if v != i {
+				t.Fatalf("concurrency %d: item %d out of order: %d", concurrency, i, v)
+			}
+		}
+		if calls != 13 {
+			t.Errorf("concurrency %d: expected 13 page fetches, got %d", concurrency, calls)
+		}
+		if p.HasNext() {
+			t.Errorf("concurrency %d: pager should be exhausted", concurrency)
+		}
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls int32
+	fetch := fakePages(100, 10, &calls)
+	p := newPager(1, func(ctx context.Context, page int) ([]int, *Pagination, error) {
+		if page == 5 {
+			return nil, nil, boom
+		}
+		return fetch(ctx, page)
+	})
+	p.Concurrency = 3
+
+	if _, err := p.All(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+}
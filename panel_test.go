@@ -0,0 +1,17 @@
+package alligator_test
+
+import (
+	"github.com/m41denx/alligator/alligatortest"
+	"testing"
+)
+
+// survival is the identifier of the seeded server.
+const survival = alligatortest.DefaultServer
+
+// newPanel starts a fake panel seeded with alligatortest.DefaultFixtures and closes it with the test.
+func newPanel(t *testing.T) *alligatortest.Panel {
+	p := alligatortest.NewPanel()
+	t.Cleanup(p.Close)
+	p.Seed(alligatortest.DefaultFixtures())
+	return p
+}
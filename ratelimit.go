@@ -0,0 +1,150 @@
+package alligator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The panel hands out X-RateLimit-Limit requests per minute for every API key.
+const rateLimitWindow = time.Minute
+
+// RateLimiter is a token bucket seeded from the X-RateLimit-* headers returned by the panel.
+// Until the first response arrives the limit is unknown and requests are not throttled.
+type RateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	tokens float64
+	last   time.Time
+	until  time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{}
+}
+
+// limiters holds the shared limiters by hashed API key. Entries live until ReleaseRateLimiter
+// is called, processes creating clients for many keys should release the ones they're done with.
+var limiters = struct {
+	sync.Mutex
+	m map[string]*RateLimiter
+}{m: make(map[string]*RateLimiter)}
+
+func limiterID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// SharedRateLimiter returns the limiter shared by every Application and Client using the same API key.
+func SharedRateLimiter(key string) *RateLimiter {
+	id := limiterID(key)
+
+	limiters.Lock()
+	defer limiters.Unlock()
+
+	l, ok := limiters.m[id]
+	if !ok {
+		l = NewRateLimiter()
+		limiters.m[id] = l
+	}
+
+	return l
+}
+
+// ReleaseRateLimiter forgets the shared limiter of an API key, once no Application or Client uses
+// the key anymore. Instances still holding the old limiter keep using it, new ones get a fresh limiter.
+func ReleaseRateLimiter(key string) {
+	limiters.Lock()
+	defer limiters.Unlock()
+
+	delete(limiters.m, limiterID(key))
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait, reserved := l.reserve(time.Now())
+	if err := sleep(ctx, wait); err != nil {
+		if reserved {
+			l.mu.Lock()
+			l.tokens++
+			l.mu.Unlock()
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) reserve(now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var wait time.Duration
+	reserved := l.limit > 0
+	if reserved {
+		l.refill(now)
+		l.tokens--
+		if l.tokens < 0 {
+			wait = time.Duration(math.Ceil(-l.tokens / l.rate()))
+		}
+	}
+
+	if blocked := l.until.Sub(now); blocked > wait {
+		wait = blocked
+	}
+
+	return wait, reserved
+}
+
+// rate returns the number of tokens regained per nanosecond.
+func (l *RateLimiter) rate() float64 {
+	return float64(l.limit) / float64(rateLimitWindow)
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.tokens+float64(elapsed)*l.rate(), float64(l.limit))
+	}
+	l.last = now
+}
+
+// Update adjusts the bucket from the rate limit headers of a panel response. A 429 blocks the
+// limiter for Retry-After, or a whole window without it, even when the other headers are missing.
+func (l *RateLimiter) Update(res *http.Response) {
+	limit, err := strconv.Atoi(res.Header.Get("X-RateLimit-Limit"))
+	remaining, rerr := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	known := err == nil && rerr == nil && limit > 0
+	if !known && res.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	switch {
+	case !known:
+	case l.limit == 0:
+		l.limit = limit
+		l.tokens = float64(remaining)
+		l.last = now
+	default:
+		l.limit = limit
+		l.refill(now)
+		l.tokens = min(l.tokens, float64(remaining))
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		after, ok := retryAfter(res)
+		if !ok {
+			after = rateLimitWindow
+		}
+		l.tokens = min(l.tokens, 0)
+		l.until = now.Add(after)
+	}
+}
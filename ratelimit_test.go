@@ -0,0 +1,71 @@
+package alligator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter()
+	if wait, _ := l.reserve(time.Now()); wait != 0 {
+		t.Fatalf("unknown limit should not throttle, got %s", wait)
+	}
+
+	res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	res.Header.Set("X-RateLimit-Limit", "60")
+	res.Header.Set("X-RateLimit-Remaining", "2")
+	l.Update(res)
+
+	now := l.last
+	for i := 0; i < 2; i++ {
+		if wait, _ := l.reserve(now); wait != 0 {
+			t.Fatalf("request %d should not wait, got %s", i, wait)
+		}
+	}
+	// 60 requests per minute means one token every second, queued callers wait in turn
+	if wait, _ := l.reserve(now); wait != time.Second {
+		t.Errorf("expected 1s wait, got %s", wait)
+	}
+	if wait, _ := l.reserve(now); wait != 2*time.Second {
+		t.Errorf("expected 2s wait, got %s", wait)
+	}
+
+	res.StatusCode = http.StatusTooManyRequests
+	res.Header.Set("X-RateLimit-Remaining", "0")
+	res.Header.Set("Retry-After", "30")
+	l.Update(res)
+	if wait, _ := l.reserve(time.Now()); wait < 29*time.Second {
+		t.Errorf("expected to wait for Retry-After, got %s", wait)
+	}
+
+	// A bare 429 still blocks the limiter, for Retry-After or a whole window
+	bare := NewRateLimiter()
+	bare.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"5"}}})
+	if wait, _ := bare.reserve(time.Now()); wait < 4*time.Second || wait > 5*time.Second {
+		t.Errorf("expected to wait for Retry-After without rate limit headers, got %s", wait)
+	}
+	bare = NewRateLimiter()
+	bare.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	if wait, _ := bare.reserve(time.Now()); wait < rateLimitWindow-time.Second {
+		t.Errorf("expected to back off for a window without Retry-After, got %s", wait)
+	}
+
+	if SharedRateLimiter("ptla_a") != SharedRateLimiter("ptla_a") {
+		t.Error("limiters for the same key should be shared")
+	}
+	if SharedRateLimiter("ptla_a") == SharedRateLimiter("ptla_b") {
+		t.Error("limiters for different keys should not be shared")
+	}
+
+	shared := SharedRateLimiter("ptla_a")
+	ReleaseRateLimiter("ptla_a")
+	if SharedRateLimiter("ptla_a") == shared {
+		t.Error("expected a fresh limiter after releasing the key")
+	}
+	ReleaseRateLimiter("ptla_a")
+	ReleaseRateLimiter("ptla_b")
+	if _, ok := limiters.m[limiterID("ptla_a")]; ok {
+		t.Error("expected the released limiter to be removed")
+	}
+}
@@ -0,0 +1,136 @@
+package alligator
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts        int           // Total number of attempts, including the first one
+	BaseDelay          time.Duration // Delay before the first retry, doubled after every attempt
+	MaxDelay           time.Duration // Upper bound for the backoff delay and for Retry-After
+	Jitter             float64       // Fraction of the delay that is randomised, between 0 and 1
+	RetryNonIdempotent bool          // Also retry POST and PATCH requests
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body can't be replayed
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return p.RetryNonIdempotent
+	}
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if after, ok := retryAfter(res); ok {
+			// Don't let a panel or proxy park the caller for an hour
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				after = p.MaxDelay
+			}
+			return after
+		}
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		delay -= time.Duration(float64(delay) * jitter * rand.Float64())
+	}
+
+	return delay
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), true
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be replayed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+
+	return next, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,63 @@
+package alligator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"object":"list","data":[]}`))
+	}))
+	defer srv.Close()
+
+	app, _ := NewApp(srv.URL, "ptla_test")
+	app.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	if _, err := app.ListLocations(); err != nil {
+		t.Fatalf("expected retries to succeed, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+
+	// POST is not retried unless explicitly allowed
+	atomic.StoreInt32(&calls, 0)
+	if _, err := app.CreateLocation("us", "United States"); err == nil {
+		t.Fatal("expected the 503 to be returned")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt for POST, got %d", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	app.Retry.RetryNonIdempotent = true
+	if _, err := app.CreateLocation("us", "United States"); err != nil {
+		t.Fatalf("expected retries to succeed, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryAfterCap(t *testing.T) {
+	res := &http.Response{Header: http.Header{"Retry-After": {"3600"}}}
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	if d := p.delay(2, res); d != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped at MaxDelay, got %s", d)
+	}
+
+	p.MaxDelay = 0
+	if d := p.delay(2, res); d != time.Hour {
+		t.Errorf("expected Retry-After to be used without MaxDelay, got %s", d)
+	}
+}
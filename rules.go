@@ -0,0 +1,388 @@
+package alligator
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule is a single Laravel validation rule, e.g. "max:20" is Rule{Name: "max", Args: []string{"20"}}.
+type Rule struct {
+	Name string
+	Args []string
+	re   *regexp.Regexp
+}
+
+func (r Rule) String() string {
+	if len(r.Args) == 0 {
+		return r.Name
+	}
+	return r.Name + ":" + strings.Join(r.Args, ",")
+}
+
+// ParseRules parses a Laravel rule string such as "required|string|max:20|regex:/^[a-z]+$/".
+// Regex patterns may contain "|" and are translated from PCRE to Go, patterns Go can't compile
+// (lookarounds, backreferences) are reported as errors. Rules other than required, nullable, string,
+// integer, numeric, boolean, min, max, between, in, regex, not_regex, alpha_dash and url are kept but
+// never fail.
+func ParseRules(rules string) ([]Rule, error) {
+	var out []Rule
+	for s := strings.TrimSpace(rules); s != ""; s = strings.TrimPrefix(s, "|") {
+		var raw string
+		if strings.HasPrefix(s, "regex:") || strings.HasPrefix(s, "not_regex:") {
+			start := strings.IndexByte(s, ':') + 1
+			n := patternEnd(s[start:])
+			if n < 0 {
+				return nil, fmt.Errorf("%s: unterminated pattern", s)
+			}
+			end := start + n
+			for end < len(s) && s[end] != '|' {
+				end++
+			}
+			raw, s = s[:end], s[end:]
+		} else {
+			raw, s, _ = strings.Cut(s, "|")
+		}
+
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		rule, err := parseRule(raw)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+func parseRule(raw string) (Rule, error) {
+	name, args, _ := strings.Cut(raw, ":")
+	rule := Rule{Name: strings.ToLower(strings.TrimSpace(name))}
+
+	switch rule.Name {
+	case "regex", "not_regex":
+		re, err := compilePattern(args)
+		if err != nil {
+			return rule, fmt.Errorf("%s: %w", raw, err)
+		}
+		rule.Args, rule.re = []string{args}, re
+		return rule, nil
+	}
+
+	if args != "" {
+		r := csv.NewReader(strings.NewReader(args))
+		r.TrimLeadingSpace = true
+		fields, err := r.Read()
+		if err != nil {
+			return rule, fmt.Errorf("%s: %w", raw, err)
+		}
+		rule.Args = fields
+	}
+
+	want := map[string]int{"min": 1, "max": 1, "between": 2}[rule.Name]
+	if want > 0 {
+		if len(rule.Args) != want {
+			return rule, fmt.Errorf("%s: expected %d argument(s)", raw, want)
+		}
+		for _, arg := range rule.Args {
+			if _, err := strconv.ParseFloat(arg, 64); err != nil {
+				return rule, fmt.Errorf("%s: %q is not a number", raw, arg)
+			}
+		}
+	}
+	if rule.Name == "in" && len(rule.Args) == 0 {
+		return rule, fmt.Errorf("%s: expected at least one value", raw)
+	}
+
+	return rule, nil
+}
+
+// patternEnd returns the length of a delimited PCRE pattern like "/^a|b$/", without its flags.
+func patternEnd(p string) int {
+	if p == "" {
+		return -1
+	}
+	closing := p[0]
+	if i := strings.IndexByte("({[<", closing); i >= 0 {
+		closing = ")}]>"[i]
+	}
+	for i := 1; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			i++
+		case closing:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func compilePattern(p string) (*regexp.Regexp, error) {
+	n := patternEnd(p)
+	if n < 0 {
+		return nil, errors.New("unterminated pattern")
+	}
+
+	var flags string
+	for _, f := range p[n:] {
+		switch f {
+		case 'i', 'm', 's', 'U':
+			flags += string(f)
+		case 'u', 'D':
+			// Go patterns are always UTF-8 and "$" only matches at the end without "m"
+		default:
+			return nil, fmt.Errorf("unsupported pattern modifier %q", f)
+		}
+	}
+	if flags != "" {
+		flags = "(?" + flags + ")"
+	}
+
+	return regexp.Compile(flags + p[1:n-1])
+}
+
+// EnvironmentError is returned by ValidateEnvironment. Fields maps each environment variable
+// (e.g. "SERVER_JARFILE") to the rules its value failed.
+type EnvironmentError struct {
+	Fields map[string][]FieldError
+}
+
+func (e *EnvironmentError) Error() string {
+	vars := make([]string, 0, len(e.Fields))
+	for env, errs := range e.Fields {
+		if len(errs) > 0 {
+			vars = append(vars, env)
+		}
+	}
+	if len(vars) == 0 {
+		return "invalid environment"
+	}
+	sort.Strings(vars)
+
+	detail := e.Fields[vars[0]][0].Detail
+	if len(vars) > 1 {
+		detail = fmt.Sprintf("%s (and %d more variable(s))", detail, len(vars)-1)
+	}
+	return fmt.Sprintf("invalid environment: %s", detail)
+}
+
+func (e *EnvironmentError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ValidateEnvironment checks env against the rules of every variable, the same way the panel does
+// when a server is created or its startup is updated. Missing and blank values count as null.
+// It returns an *EnvironmentError when values are rejected.
+func ValidateEnvironment(vars []*EggVariable, env map[string]interface{}) error {
+	fields := make(map[string][]FieldError)
+	for _, v := range vars {
+		errs, err := v.Validate(env[v.EnvVariable])
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.EnvVariable, err)
+		}
+		if len(errs) > 0 {
+			fields[v.EnvVariable] = errs
+		}
+	}
+
+	if len(fields) > 0 {
+		return &EnvironmentError{Fields: fields}
+	}
+	return nil
+}
+
+// ValidateEnvironment checks env against the egg's variables, which must have been included.
+func (e *Egg) ValidateEnvironment(env map[string]interface{}) error {
+	return ValidateEnvironment(e.Variables, env)
+}
+
+// Validate checks a single value against the variable's rules. A nil value stands for a missing one.
+func (v *EggVariable) Validate(value interface{}) ([]FieldError, error) {
+	rules, err := ParseRules(v.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := v.EnvVariable
+	if v.Name != "" {
+		attribute = v.Name + " variable"
+	}
+	if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+		value = nil
+	}
+
+	numericSize := hasRule(rules, "integer") || hasRule(rules, "numeric")
+	var errs []FieldError
+	for _, rule := range rules {
+		if value == nil {
+			if rule.Name == "required" {
+				return []FieldError{{Rule: "required", Detail: fmt.Sprintf("The %s field is required.", attribute)}}, nil
+			}
+			if hasRule(rules, "nullable") {
+				break
+			}
+		}
+		if detail := checkRule(rule, value, numericSize); detail != "" {
+			detail = strings.ReplaceAll(detail, ":attribute", attribute)
+			errs = append(errs, FieldError{Rule: rule.Name, Detail: detail})
+		}
+	}
+	return errs, nil
+}
+
+func hasRule(rules []Rule, name string) bool {
+	for _, r := range rules {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	numericPattern   = regexp.MustCompile(`^\s*[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?\s*$`)
+	integerPattern   = regexp.MustCompile(`^\s*[+-]?\d+\s*$`)
+	alphaDashPattern = regexp.MustCompile(`^[\pL\pM\pN_-]+$`)
+)
+
+// checkRule returns the failure message of the rule, or an empty string when the value passes.
+func checkRule(rule Rule, value interface{}, numericSize bool) string {
+	switch rule.Name {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return "The :attribute must be a string."
+		}
+	case "integer":
+		if !isInteger(value) {
+			return "The :attribute must be an integer."
+		}
+	case "numeric":
+		if _, ok := toNumber(value); !ok {
+			return "The :attribute must be a number."
+		}
+	case "boolean":
+		switch toString(value) {
+		case "", "0", "1":
+		default:
+			return "The :attribute field must be true or false."
+		}
+	case "min", "max", "between":
+		size, unit := valueSize(value, numericSize)
+		lo, _ := strconv.ParseFloat(rule.Args[0], 64)
+		hi := lo
+		if rule.Name == "between" {
+			hi, _ = strconv.ParseFloat(rule.Args[1], 64)
+		}
+		switch {
+		case rule.Name == "min" && size < lo:
+			return fmt.Sprintf("The :attribute must be at least %s%s.", rule.Args[0], unit)
+		case rule.Name == "max" && size > hi:
+			return fmt.Sprintf("The :attribute may not be greater than %s%s.", rule.Args[0], unit)
+		case rule.Name == "between" && (size < lo || size > hi):
+			return fmt.Sprintf("The :attribute must be between %s and %s%s.", rule.Args[0], rule.Args[1], unit)
+		}
+	case "in":
+		for _, arg := range rule.Args {
+			if toString(value) == arg {
+				return ""
+			}
+		}
+		return "The selected :attribute is invalid."
+	case "regex", "not_regex":
+		if !isStringOrNumber(value) || rule.re.MatchString(toString(value)) != (rule.Name == "regex") {
+			return "The :attribute format is invalid."
+		}
+	case "alpha_dash":
+		if !isStringOrNumber(value) || !alphaDashPattern.MatchString(toString(value)) {
+			return "The :attribute may only contain letters, numbers, dashes and underscores."
+		}
+	case "url":
+		s, ok := value.(string)
+		if !ok {
+			return "The :attribute format is invalid."
+		}
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" || u.Host == "" || strings.ContainsAny(s, " \t\n") {
+			return "The :attribute format is invalid."
+		}
+	}
+	return ""
+}
+
+// valueSize returns what min, max and between compare: the number itself when the rules ask for
+// a number, the length of the value otherwise.
+func valueSize(value interface{}, numeric bool) (float64, string) {
+	if numeric {
+		if n, ok := toNumber(value); ok {
+			return n, ""
+		}
+	}
+	return float64(utf8.RuneCountInString(toString(value))), " characters"
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case string:
+		if !numericPattern.MatchString(v) {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		n, err := strconv.ParseFloat(toString(v), 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func isInteger(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return integerPattern.MatchString(v)
+	case float64:
+		return v == math.Trunc(v)
+	case float32:
+		return float64(v) == math.Trunc(float64(v))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isStringOrNumber(value interface{}) bool {
+	if _, ok := value.(string); ok {
+		return true
+	}
+	_, ok := toNumber(value)
+	return ok
+}
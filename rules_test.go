@@ -0,0 +1,97 @@
+package alligator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(`required|regex:/^(latest|[0-9.]+)$/i|in:"a,b",c|max:20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 rules, got %v", rules)
+	}
+	if rules[1].Name != "regex" || !rules[1].re.MatchString("LATEST") || rules[1].re.MatchString("1.20|x") {
+		t.Errorf("regex wasn't parsed with its flags: %v", rules[1])
+	}
+	if len(rules[2].Args) != 2 || rules[2].Args[0] != "a,b" {
+		t.Errorf("unexpected in arguments: %q", rules[2].Args)
+	}
+
+	for _, bad := range []string{"max:ten", "between:1", "regex:/[a-z", "regex:/(?=a)/", "regex:/a/x", "in"} {
+		if _, err = ParseRules(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestValidateEnvironment(t *testing.T) {
+	vars := []*EggVariable{
+		{Name: "Server Jar File", EnvVariable: "SERVER_JARFILE", Rules: `required|regex:/^([\w\d._-]+)(\.jar)$/`},
+		{Name: "Version", EnvVariable: "VERSION", Rules: "nullable|string|max:20"},
+		{Name: "Max Players", EnvVariable: "MAX_PLAYERS", Rules: "required|integer|between:1,100"},
+		{Name: "Online Mode", EnvVariable: "ONLINE_MODE", Rules: "boolean"},
+		{Name: "Build", EnvVariable: "BUILD", Rules: "required|alpha_dash|in:stable,beta"},
+		{Name: "Download", EnvVariable: "DL_PATH", Rules: "nullable|url"},
+		{Name: "Motd", EnvVariable: "MOTD", Rules: "string|min:3"},
+	}
+
+	err := ValidateEnvironment(vars, map[string]interface{}{
+		"SERVER_JARFILE": "server.jar",
+		"VERSION":        "",
+		"MAX_PLAYERS":    float64(20),
+		"ONLINE_MODE":    "1",
+		"BUILD":          "stable",
+		"MOTD":           "héllo",
+	})
+	if err != nil {
+		t.Fatalf("expected a valid environment, got %v", err)
+	}
+
+	err = ValidateEnvironment(vars, map[string]interface{}{
+		"SERVER_JARFILE": "server.zip",
+		"VERSION":        "a very long version string",
+		"MAX_PLAYERS":    "250",
+		"ONLINE_MODE":    "yes",
+		"DL_PATH":        "not a url",
+		"MOTD":           "hi",
+	})
+	var eerr *EnvironmentError
+	if !errors.As(err, &eerr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected an environment error, got %v", err)
+	}
+
+	want := map[string]string{
+		"SERVER_JARFILE": "The Server Jar File variable format is invalid.",
+		"VERSION":        "The Version variable may not be greater than 20 characters.",
+		"MAX_PLAYERS":    "The Max Players variable must be between 1 and 100.",
+		"ONLINE_MODE":    "The Online Mode variable field must be true or false.",
+		"BUILD":          "The Build variable field is required.",
+		"DL_PATH":        "The Download variable format is invalid.",
+		"MOTD":           "The Motd variable must be at least 3 characters.",
+	}
+	if len(eerr.Fields) != len(want) {
+		t.Errorf("expected %d failing variables, got %v", len(want), eerr.Fields)
+	}
+	for env, detail := range want {
+		if errs := eerr.Fields[env]; len(errs) != 1 || errs[0].Detail != detail {
+			t.Errorf("%s: expected %q, got %+v", env, detail, errs)
+		}
+	}
+
+	if msg := eerr.Error(); msg != "invalid environment: The Build variable field is required. (and 6 more variable(s))" {
+		t.Errorf("unexpected message %q", msg)
+	}
+	for _, e := range []*EnvironmentError{{}, {Fields: map[string][]FieldError{"X": {}}}} {
+		if msg := e.Error(); msg != "invalid environment" {
+			t.Errorf("expected a generic message without fields, got %q", msg)
+		}
+	}
+
+	bad := []*EggVariable{{EnvVariable: "X", Rules: "regex:/(a)\\1/"}}
+	if err = ValidateEnvironment(bad, nil); err == nil || errors.As(err, &eerr) {
+		t.Errorf("expected a rule parsing error, got %v", err)
+	}
+}